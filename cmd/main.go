@@ -3,21 +3,29 @@ package main
 import (
 	"context"
 	"errors"
+	"expvar"
+	"fmt"
+	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
+	"time"
 
 	_ "github.com/lib/pq"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 	"github.com/jmoiron/sqlx"
 	"github.com/sirupsen/logrus"
+	"github.com/speeddem0n/GoNewsBot/internal/alert"
 	bot "github.com/speeddem0n/GoNewsBot/internal/botcmd"
 	"github.com/speeddem0n/GoNewsBot/internal/botcmd/middleware"
 	"github.com/speeddem0n/GoNewsBot/internal/botkit"
 	"github.com/speeddem0n/GoNewsBot/internal/config"
 	"github.com/speeddem0n/GoNewsBot/internal/fetcher"
+	"github.com/speeddem0n/GoNewsBot/internal/i18n"
 	"github.com/speeddem0n/GoNewsBot/internal/notifier"
+	"github.com/speeddem0n/GoNewsBot/internal/roles"
 	"github.com/speeddem0n/GoNewsBot/internal/storage"
 	"github.com/speeddem0n/GoNewsBot/internal/summary"
 )
@@ -36,54 +44,117 @@ func main() {
 	}
 	defer db.Close() // Откладываем закрытие соеденения с бд
 
+	messenger := botkit.NewTelegramMessenger(botAPI) // Клиент телеграма для View и воркеров
+
+	usage := storage.NewUsageStorage(db) // Слой хранилища расхода токенов на саммари
+
+	summarizer, err := newSummarizer(usage) // Провайдер саммари выбирается по имени из конфига
+	if err != nil {
+		logrus.Errorf("failed to create summarizer: %v", err)
+		return
+	}
+
 	var ( // Инициализация зависимостей
-		articleStorage = storage.NewArticleStorage(db) // Слой хранилища статей
-		sourceStorage  = storage.NewSourceStorage(db)  // Слой хранилища источников
-		fetcher        = fetcher.NewFetcher(           // Слой fetcher который забирает статьи из источников
+		articleStorage = storage.NewArticleStorage(db)                   // Слой хранилища статей
+		sourceStorage  = storage.NewSourceStorage(db)                    // Слой хранилища источников
+		roleStorage    = storage.NewRoleStorage(db)                      // Слой хранилища ролей пользователей
+		alertStorage   = storage.NewAlertStorage(db)                     // Слой хранилища алертов пользователей
+		subscriptions  = storage.NewSubscriptionStorage(db)              // Слой хранилища подписок пользователей
+		bookmarks      = storage.NewBookmarkStorage(db)                  // Слой хранилища закладок пользователей
+		chatSettings   = storage.NewChatSettingsStorage(db)              // Слой хранилища настроек чатов
+		locales        = i18n.NewResolver(chatSettings, defaultLocale()) // Выбор языка сообщений бота
+		fetcher        = fetcher.NewFetcher(                             // Слой fetcher который забирает статьи из источников
 			articleStorage,
 			sourceStorage,
+			alert.NewDispatcher(alertStorage, messenger, locales),
 			config.Get().FetchInterval,
 			config.Get().FilterKeywords,
 		)
 		notifier = notifier.NewNotifier( // слой notifier
 			articleStorage,
-			summary.NewOpenAISummarizer(config.Get().OpenAIKey, config.Get().OpenAIPrompt),
-			botAPI,
+			subscriptions,
+			summarizer,
+			messenger,
+			locales,
 			config.Get().NotificationInterval,
 			config.Get().LookupTimeWindow, // lookupTimeWindow равен двум FetchInterval
 			config.Get().TelegramChannelID,
 		)
 	)
 
+	roleManager := roles.NewManager( // Роли пользователей, администраторы канала кэшируются
+		messenger,
+		roleStorage,
+		config.Get().TelegramChannelID,
+		config.Get().AdminsRefreshInterval,
+	)
+
 	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM) // Контекст для Graceful shutdown
 	defer cancel()
 
-	newsBot := botkit.NewBot(botAPI)                    // Инициализируем тг бота
-	newsBot.RegisterCmdView("help", bot.ViewCmdStart()) // Инициализируем View для команды start
+	conversations := botkit.NewConversationManager(config.Get().ConversationTTL) // Хранилище пошаговых диалогов с пользователями
 
-	newsBot.RegisterCmdView( // Инициализируем View для команды add
-		"add",
-		middleware.AdminOnly(
-			config.Get().TelegramChannelID,
-			bot.ViewCmdAddSource(sourceStorage),
-		),
+	newsBot := botkit.NewBot( // Инициализируем тг бота
+		botAPI,
+		messenger,
+		conversations,
+		roleManager,
+		config.Get().UpdateWorkers,
+		config.Get().ShutdownTimeout,
 	)
-
-	newsBot.RegisterCmdView( // Инициализируем View для команды list
-		"list",
-		middleware.AdminOnly(
-			config.Get().TelegramChannelID,
-			bot.ViewCmdListSources(sourceStorage),
-		),
-	)
-	newsBot.RegisterCmdView( // Инициализируем View для команды delete
-		"delete",
-		middleware.AdminOnly(
-			config.Get().TelegramChannelID,
-			bot.ViewCmdDelete(sourceStorage),
-		),
+	newsBot.Use( // Глобальные middleware, первая в списке оборачивает все остальные
+		middleware.Recover(),
+		middleware.Logging(),
+		middleware.Metrics(),
+		middleware.RateLimit(config.Get().RateLimit, config.Get().RateLimitInterval), // До Localize, чтобы флуд не создавал запросы к БД
+		middleware.Localize(locales),
+		middleware.Timeout(config.Get().UpdateTimeout),
 	)
 
+	newsBot.RegisterChatMemberView(roleManager.ViewChatMemberUpdate()) // Изменения администраторов канала сразу попадают в кэш ролей
+
+	// Права на команду и ее описание для меню и /help задаются в botkit.Command рядом с View
+	newsBot.RegisterCmdView(bot.CmdHelp, bot.ViewCmdHelp(newsBot, roleManager))                                      // Инициализируем View для команды help
+	newsBot.RegisterCmdView(bot.CmdStart, bot.ViewCmdHelp(newsBot, roleManager))                                     // Инициализируем View для команды start
+	newsBot.RegisterCmdView(bot.CmdCancel, conversations.ViewCancel())                                               // Инициализируем View для отмены текущего диалога
+	newsBot.RegisterCmdView(bot.CmdListSources, bot.ViewCmdListSources(sourceStorage))                               // Инициализируем View для команды list
+	newsBot.RegisterCmdView(bot.CmdSource, bot.ViewCmdSource(sourceStorage, articleStorage))                         // Инициализируем View для команды source
+	newsBot.RegisterCmdView(bot.CmdAddSource, bot.ViewCmdAddSource(sourceStorage, conversations))                    // Инициализируем View для команды add
+	newsBot.RegisterCmdView(bot.CmdEditSource, bot.ViewCmdEditSource(sourceStorage, conversations))                  // Инициализируем View для команды edit
+	newsBot.RegisterCmdView(bot.CmdDeleteSource, bot.ViewCmdDelete(sourceStorage, conversations))                    // Инициализируем View для команды delete
+	newsBot.RegisterCmdView(bot.CmdGrant, bot.ViewCmdGrant(roleManager))                                             // Инициализируем View для команды grant
+	newsBot.RegisterCmdView(bot.CmdRevoke, bot.ViewCmdRevoke(roleManager))                                           // Инициализируем View для команды revoke
+	newsBot.RegisterCmdView(bot.CmdSources, bot.ViewCmdSources(sourceStorage, subscriptions))                        // Инициализируем View для команды sources
+	newsBot.RegisterCmdView(bot.CmdSubscribe, bot.ViewCmdSubscribe(sourceStorage, subscriptions))                    // Инициализируем View для команды subscribe
+	newsBot.RegisterCmdView(bot.CmdUnsubscribe, bot.ViewCmdUnsubscribe(sourceStorage, subscriptions))                // Инициализируем View для команды unsubscribe
+	newsBot.RegisterCmdView(bot.CmdAlert, bot.ViewCmdAlert(alertStorage))                                            // Инициализируем View для команды alert
+	newsBot.RegisterCmdView(bot.CmdSearch, bot.ViewCmdSearch(articleStorage))                                        // Инициализируем View для команды search
+	newsBot.RegisterCallbackView(bot.SearchCallback, bot.ViewSearchPage(articleStorage))                             // Инициализируем View для кнопок листания результатов поиска
+	newsBot.RegisterCmdView(bot.CmdLatest, bot.ViewCmdLatest(articleStorage, sourceStorage))                         // Инициализируем View для команды latest
+	newsBot.RegisterCallbackView(bot.LatestCallback, bot.ViewLatestPage(articleStorage, sourceStorage))              // Инициализируем View для кнопок листания последних статей
+	newsBot.RegisterCallbackView(bot.SourceCallback, bot.ViewSourcePage(sourceStorage, articleStorage))              // Инициализируем View для кнопок листания статей источника
+	newsBot.RegisterInlineView(bot.ViewInlineSearch(articleStorage))                                                 // Инициализируем View для inline запросов @bot <запрос>
+	newsBot.RegisterCmdView(bot.CmdSaved, bot.ViewCmdSaved(bookmarks))                                               // Инициализируем View для команды saved
+	newsBot.RegisterCallbackView(bot.SavedCallback, bot.ViewSavedPage(bookmarks))                                    // Инициализируем View для кнопок листания закладок
+	newsBot.RegisterCallbackView(bot.RemoveBookmarkCallback, bot.ViewRemoveBookmark(bookmarks))                      // Инициализируем View для кнопок удаления закладок
+	newsBot.RegisterCallbackView(botkit.SaveArticleCallback, bot.ViewSaveArticle(bookmarks))                         // Инициализируем View для кнопки "Сохранить" под статьями
+	newsBot.RegisterCmdView(bot.CmdLang, bot.ViewCmdLang(chatSettings, roleManager, config.Get().TelegramChannelID)) // Инициализируем View для команды lang
+	newsBot.RegisterCmdView(bot.CmdUsage, bot.ViewCmdUsage(usage, config.Get().SummarizerBudget))                    // Инициализируем View для команды usage
+
+	if addr := config.Get().MetricsAddr; addr != "" { // Запуск HTTP сервера с метриками
+		go func() {
+			if err := http.ListenAndServe(addr, expvar.Handler()); err != nil {
+				logrus.Errorf("failed to start metrics server: %v", err)
+			}
+		}()
+	}
+
+	go func(ctx context.Context) { // Запуск обновления списка администраторов канала
+		if err := roleManager.Start(ctx); err != nil && !errors.Is(err, context.Canceled) {
+			logrus.Errorf("failed to start role manager: %v", err)
+		}
+	}(ctx)
+
 	go func(ctx context.Context) { // Запуск первого воркера (Fetcher)
 		if err := fetcher.Start(ctx); err != nil {
 			if !errors.Is(err, context.Canceled) { // если ошибка != остановке контекста, логируем и выходим из горутины
@@ -107,7 +178,34 @@ func main() {
 		}
 	}(ctx)
 
-	if err := newsBot.Start(ctx); err != nil { // Запуск телеграм бота
+	if interval := config.Get().PresummarizeInterval; interval > 0 { // Запуск воркера который заранее строит саммари статей из очереди
+		go func(ctx context.Context) {
+			if err := notifier.StartPresummarizer(ctx, interval, uint64(config.Get().PresummarizeBatch)); err != nil && !errors.Is(err, context.Canceled) {
+				logrus.Errorf("failed to start presummarizer: %v", err)
+			}
+		}(ctx)
+	}
+
+	var startBot func(ctx context.Context) error // Способ получения апдейтов выбирается в конфиге
+
+	switch config.Get().BotMode {
+	case "polling":
+		startBot = newsBot.Start
+	case "webhook":
+		startBot = func(ctx context.Context) error {
+			return newsBot.StartWebhook(ctx, botkit.WebhookConfig{
+				ListenAddr:  config.Get().WebhookListenAddr,
+				Path:        config.Get().WebhookPath,
+				URL:         config.Get().WebhookURL,
+				SecretToken: config.Get().WebhookSecret,
+			})
+		}
+	default:
+		logrus.Errorf("unknown bot mode %q, expected polling or webhook", config.Get().BotMode)
+		return
+	}
+
+	if err := startBot(ctx); err != nil { // Запуск телеграм бота
 		if !errors.Is(err, context.Canceled) { // если ошибка != остановке контекста, логируем и выходим из горутины
 			logrus.Errorf("failed to start tg bot: %v", err)
 			return
@@ -116,3 +214,119 @@ func main() {
 		logrus.Println("bot stopped")
 	}
 }
+
+func defaultLocale() i18n.Locale { // Функция возвращает язык по умолчанию из конфига
+	locale, ok := i18n.Parse(config.Get().DefaultLocale)
+	if !ok {
+		logrus.Warnf("unsupported default locale %q, using %q", config.Get().DefaultLocale, locale)
+	}
+
+	return locale
+}
+
+func newSummarizer(usage *storage.UsagePostgresStorage) (summary.Summarizer, error) { // Функция собирает цепочку провайдеров саммари: основной провайдер и запасные из конфига
+	templates, err := summary.LoadPromptTemplates(config.Get().PromptDir)
+	if err != nil {
+		return nil, fmt.Errorf("load prompt templates: %w", err)
+	}
+
+	prompts, err := summary.NewPrompts(summary.PromptConfig{ // Шаблоны запроса к модели, выбираются по источнику или каналу
+		Templates: templates,
+		Sources:   config.Get().SourcePrompts,
+		Channels:  config.Get().ChannelPrompts,
+		Default:   config.Get().OpenAIPrompt, // NFB_OPENAI_PROMPT остается для совместимости и заменяет шаблон по умолчанию
+		Length:    config.Get().SummarizerSentences,
+		Translate: config.Get().SummarizerTranslate,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := summarizerConfig()
+	cfg.Prompts = prompts
+	cfg.Usage = usage
+
+	primary, err := summary.New(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	providers := []summary.ChainProvider{{
+		Name:       cfg.Provider,
+		Summarizer: primary,
+		Timeout:    config.Get().SummarizerTimeout,
+		Budget:     summary.NewBudget(usage, config.Get().SummarizerBudget), // Бюджет ограничивает только основной провайдер, запасные обычно бесплатные
+	}}
+
+	for _, entry := range config.Get().SummarizerFallback { // Запасной провайдер задается как имя или имя:таймаут, например ollama:1m
+		name, rawTimeout, _ := strings.Cut(strings.TrimSpace(entry), ":")
+		if name == "" || strings.EqualFold(name, "none") || strings.EqualFold(name, cfg.Provider) {
+			continue
+		}
+
+		timeout := config.Get().SummarizerTimeout
+		if rawTimeout != "" {
+			if timeout, err = time.ParseDuration(rawTimeout); err != nil {
+				return nil, fmt.Errorf("fallback %s: invalid timeout: %w", name, err)
+			}
+		}
+
+		fallback, err := summary.New(summary.ProviderConfig{ // Адрес, модель и ключ относятся к основному провайдеру, запасные используют свои значения по умолчанию
+			Provider:    name,
+			Prompts:     cfg.Prompts,
+			Temperature: cfg.Temperature,
+			MaxTokens:   cfg.MaxTokens,
+			Sentences:   cfg.Sentences,
+
+			Concurrency:     cfg.Concurrency,
+			TokensPerMinute: cfg.TokensPerMinute,
+
+			InputTokens: cfg.InputTokens,
+			MapReduce:   cfg.MapReduce,
+			MaxChunks:   cfg.MaxChunks,
+
+			Usage: usage, // Цены заданы для основного провайдера, у запасных записываются только токены
+		})
+		if err != nil {
+			return nil, fmt.Errorf("fallback %s: %w", name, err)
+		}
+
+		providers = append(providers, summary.ChainProvider{Name: name, Summarizer: fallback, Timeout: timeout})
+	}
+
+	return summary.NewChain(summary.ChainConfig{
+		FailureThreshold:   config.Get().SummarizerFailures,
+		Cooldown:           config.Get().SummarizerCooldown,
+		PostWithoutSummary: !config.Get().SummarizerRequired,
+		Prompts:            prompts,
+	}, providers...), nil
+}
+
+func summarizerConfig() summary.ProviderConfig { // Функция собирает настройки провайдера саммари из конфига
+	apiKey := config.Get().SummarizerAPIKey
+	if apiKey == "" { // NFB_OPENAI_KEY остается для совместимости со старыми конфигами
+		apiKey = config.Get().OpenAIKey
+	}
+
+	return summary.ProviderConfig{
+		Provider:    config.Get().SummarizerProvider,
+		APIKey:      apiKey,
+		BaseURL:     config.Get().SummarizerBaseURL,
+		Model:       config.Get().SummarizerModel,
+		Temperature: config.Get().SummarizerTemperature,
+		MaxTokens:   config.Get().SummarizerMaxTokens,
+		Sentences:   config.Get().SummarizerSentences,
+
+		Concurrency:     config.Get().SummarizerConcurrency,
+		TokensPerMinute: config.Get().SummarizerTPM,
+
+		InputTokens: config.Get().SummarizerInputTokens,
+		MapReduce:   config.Get().SummarizerMapReduce,
+		MaxChunks:   config.Get().SummarizerMaxChunks,
+
+		Pricing: summary.Pricing{
+			Prompt:     config.Get().SummarizerPromptPrice,
+			Completion: config.Get().SummarizerReplyPrice,
+		},
+	}
+}
@@ -0,0 +1,101 @@
+package alert
+
+import (
+	"context"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/sirupsen/logrus"
+
+	"github.com/speeddem0n/GoNewsBot/internal/botkit"
+	"github.com/speeddem0n/GoNewsBot/internal/i18n"
+	"github.com/speeddem0n/GoNewsBot/internal/models"
+)
+
+type AlertProvider interface { // Интерфейс для работы со слоем storage/alert.go
+	Alerts(ctx context.Context) ([]models.Alert, error)
+}
+
+type ChatLocaleResolver interface { // Интерфейс для получения языка личного чата пользователя
+	ChatLocale(ctx context.Context, chatID int64) i18n.Locale
+}
+
+type Dispatcher struct { // Структура для проверки новых статей по алертам пользователей
+	alerts  AlertProvider
+	bot     botkit.Messenger
+	locales ChatLocaleResolver
+}
+
+func NewDispatcher(alerts AlertProvider, bot botkit.Messenger, locales ChatLocaleResolver) *Dispatcher { // Конструктор для структуры Dispatcher
+	return &Dispatcher{
+		alerts:  alerts,
+		bot:     bot,
+		locales: locales,
+	}
+}
+
+type compiledAlert struct {
+	alert models.Alert
+	expr  Expression
+}
+
+func (d *Dispatcher) Check(ctx context.Context, articles []models.Article) error { // Метод проверяет новые статьи и сразу отправляет совпадения пользователям в личные сообщения
+	if len(articles) == 0 {
+		return nil
+	}
+
+	alerts, err := d.alerts.Alerts(ctx)
+	if err != nil {
+		return err
+	}
+
+	compiled := make([]compiledAlert, 0, len(alerts))
+	for _, alert := range alerts {
+		expr, err := Parse(alert.Expression)
+		if err != nil { // Выражения проверяются при добавлении, сюда попадают только алерты сохраненные до изменения синтаксиса
+			logrus.Errorf("failed to parse alert %d: %v", alert.ID, err)
+			continue
+		}
+
+		compiled = append(compiled, compiledAlert{alert: alert, expr: expr})
+	}
+
+	for _, article := range articles {
+		var (
+			text    = Normalize(article.Title + "\n" + article.Summary)
+			matches = make(map[int64][]string) // Сработавшие выражения по пользователям, одна статья отправляется пользователю один раз
+			users   []int64
+		)
+
+		for _, c := range compiled {
+			if !c.expr.Match(text) {
+				continue
+			}
+
+			if _, ok := matches[c.alert.UserID]; !ok {
+				users = append(users, c.alert.UserID)
+			}
+			matches[c.alert.UserID] = append(matches[c.alert.UserID], c.alert.Expression)
+		}
+
+		for _, userID := range users {
+			if err := d.send(ctx, userID, article, matches[userID]); err != nil { // Пользователь мог заблокировать бота, это не мешает остальным
+				logrus.Errorf("failed to send alert for article %q to user %d: %v", article.Link, userID, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (d *Dispatcher) send(ctx context.Context, userID int64, article models.Article, expressions []string) error {
+	locale := d.locales.ChatLocale(ctx, userID)
+
+	msg := tgbotapi.NewMessage(userID, i18n.T(locale, botkit.AlertMatchedMsg, strings.Join(expressions, "; "), article.Title, article.Link))
+
+	if _, err := d.bot.Send(msg); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,341 @@
+package alert
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+/* Синтаксис выражений:
+слова через пробел должны встречаться все: CVE openssl
+OR или | - любое из условий: nginx OR apache
+-слово или NOT - слово не должно встречаться: CVE -windows
+"фраза в кавычках" ищется целиком, скобки группируют условия: CVE (nginx | "apache httpd")
+Поиск не зависит от регистра и ищет слова целиком: go не совпадает с google */
+
+var ErrEmptyExpression = errors.New("empty expression")
+
+type Expression interface { // Разобранное выражение алерта
+	Match(text string) bool // text должен быть приведен к нижнему регистру функцией Normalize
+	String() string
+}
+
+func Normalize(text string) string { // Функция подготавливает текст статьи для Match
+	return strings.ToLower(text)
+}
+
+type termExpr struct{ term string }
+
+type notExpr struct{ expr Expression }
+
+type andExpr struct{ exprs []Expression }
+
+type orExpr struct{ exprs []Expression }
+
+func (e termExpr) Match(text string) bool {
+	for offset := 0; ; {
+		i := strings.Index(text[offset:], e.term)
+		if i < 0 {
+			return false
+		}
+
+		start, end := offset+i, offset+i+len(e.term)
+		if isBoundary(text, start, end) {
+			return true
+		}
+
+		_, size := utf8.DecodeRuneInString(text[start:]) // Продолжаем поиск со следующего символа
+		offset = start + size
+	}
+}
+
+func (e termExpr) String() string {
+	if strings.ContainsAny(e.term, " ()|") {
+		return `"` + e.term + `"`
+	}
+
+	return e.term
+}
+
+func (e notExpr) Match(text string) bool { return !e.expr.Match(text) }
+
+func (e notExpr) String() string { return "-" + e.expr.String() }
+
+func (e andExpr) Match(text string) bool {
+	for _, expr := range e.exprs {
+		if !expr.Match(text) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (e andExpr) String() string { return joinExprs(e.exprs, " ") }
+
+func (e orExpr) Match(text string) bool {
+	for _, expr := range e.exprs {
+		if expr.Match(text) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (e orExpr) String() string { return "(" + joinExprs(e.exprs, " | ") + ")" }
+
+func joinExprs(exprs []Expression, sep string) string {
+	parts := make([]string, 0, len(exprs))
+	for _, expr := range exprs {
+		parts = append(parts, expr.String())
+	}
+
+	return strings.Join(parts, sep)
+}
+
+func isBoundary(text string, start, end int) bool { // Функция проверяет что найденное слово не является частью другого слова
+	if start > 0 {
+		if r, _ := utf8.DecodeLastRuneInString(text[:start]); isWordRune(r) {
+			return false
+		}
+	}
+
+	if end < len(text) {
+		if r, _ := utf8.DecodeRuneInString(text[end:]); isWordRune(r) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_'
+}
+
+type token struct {
+	kind string // word, phrase, or, not, (, )
+	text string
+}
+
+func Parse(src string) (Expression, error) { // Функция для разбора выражения алерта
+	tokens, err := tokenize(src)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(tokens) == 0 {
+		return nil, ErrEmptyExpression
+	}
+
+	p := &parser{tokens: tokens}
+
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("unexpected %q", p.tokens[p.pos].text)
+	}
+
+	if !hasPositiveTerm(expr) { // Выражение из одних отрицаний совпадало бы почти с каждой статьей
+		return nil, errors.New("expression must contain at least one word without minus")
+	}
+
+	return expr, nil
+}
+
+func hasPositiveTerm(expr Expression) bool { // Функция проверяет что для совпадения в статье должно встретиться хотя бы одно слово
+	switch e := expr.(type) {
+	case termExpr:
+		return true
+	case andExpr:
+		for _, sub := range e.exprs {
+			if hasPositiveTerm(sub) {
+				return true
+			}
+		}
+		return false
+	case orExpr:
+		for _, sub := range e.exprs {
+			if !hasPositiveTerm(sub) {
+				return false
+			}
+		}
+		return true
+	default:
+		return false
+	}
+}
+
+func tokenize(src string) ([]token, error) {
+	var (
+		tokens []token
+		runes  = []rune(src)
+	)
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(' || r == ')':
+			tokens = append(tokens, token{kind: string(r), text: string(r)})
+			i++
+		case r == '|':
+			tokens = append(tokens, token{kind: "or", text: "|"})
+			i++
+		case r == '-' && i+1 < len(runes) && !unicode.IsSpace(runes[i+1]): // Минус перед словом означает отрицание
+			tokens = append(tokens, token{kind: "not", text: "-"})
+			i++
+		case r == '"' || r == '«' || r == '“':
+			closing := map[rune]rune{'"': '"', '«': '»', '“': '”'}[r]
+
+			end := i + 1
+			for end < len(runes) && runes[end] != closing {
+				end++
+			}
+			if end == len(runes) {
+				return nil, errors.New("unclosed quote")
+			}
+
+			phrase := strings.TrimSpace(string(runes[i+1 : end]))
+			if phrase == "" {
+				return nil, errors.New("empty phrase")
+			}
+
+			tokens = append(tokens, token{kind: "phrase", text: Normalize(phrase)})
+			i = end + 1
+		default:
+			end := i
+			for end < len(runes) && !unicode.IsSpace(runes[end]) && !strings.ContainsRune(`()|"«“`, runes[end]) {
+				end++
+			}
+
+			word := string(runes[i:end])
+			switch word {
+			case "OR":
+				tokens = append(tokens, token{kind: "or", text: word})
+			case "NOT":
+				tokens = append(tokens, token{kind: "not", text: word})
+			case "AND": // AND можно писать явно, слова через пробел и так объединяются через И
+			default:
+				tokens = append(tokens, token{kind: "word", text: Normalize(word)})
+			}
+
+			i = end
+		}
+	}
+
+	return tokens, nil
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() (token, bool) {
+	if p.pos >= len(p.tokens) {
+		return token{}, false
+	}
+
+	return p.tokens[p.pos], true
+}
+
+func (p *parser) parseOr() (Expression, error) {
+	first, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	exprs := []Expression{first}
+	for {
+		tok, ok := p.peek()
+		if !ok || tok.kind != "or" {
+			break
+		}
+		p.pos++
+
+		next, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		exprs = append(exprs, next)
+	}
+
+	if len(exprs) == 1 {
+		return first, nil
+	}
+
+	return orExpr{exprs: exprs}, nil
+}
+
+func (p *parser) parseAnd() (Expression, error) {
+	var exprs []Expression
+
+	for {
+		tok, ok := p.peek()
+		if !ok || tok.kind == "or" || tok.kind == ")" {
+			break
+		}
+
+		expr, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		exprs = append(exprs, expr)
+	}
+
+	switch len(exprs) {
+	case 0:
+		if tok, ok := p.peek(); ok {
+			return nil, fmt.Errorf("unexpected %q", tok.text)
+		}
+		return nil, errors.New("unexpected end of expression")
+	case 1:
+		return exprs[0], nil
+	default:
+		return andExpr{exprs: exprs}, nil
+	}
+}
+
+func (p *parser) parseUnary() (Expression, error) {
+	tok, _ := p.peek()
+	p.pos++
+
+	switch tok.kind {
+	case "not":
+		if _, ok := p.peek(); !ok {
+			return nil, errors.New("unexpected end of expression")
+		}
+
+		expr, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+
+		return notExpr{expr: expr}, nil
+	case "(":
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+
+		if closing, ok := p.peek(); !ok || closing.kind != ")" {
+			return nil, errors.New("missing )")
+		}
+		p.pos++
+
+		return expr, nil
+	case "word", "phrase":
+		return termExpr{term: tok.text}, nil
+	default:
+		return nil, fmt.Errorf("unexpected %q", tok.text)
+	}
+}
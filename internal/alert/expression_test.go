@@ -0,0 +1,85 @@
+package alert
+
+import "testing"
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		src     string
+		want    string
+		wantErr bool
+	}{
+		{src: "CVE", want: "cve"},
+		{src: "CVE openssl", want: "cve openssl"},
+		{src: "CVE AND openssl", want: "cve openssl"},
+		{src: "nginx OR apache", want: "(nginx | apache)"},
+		{src: "nginx | apache | caddy", want: "(nginx | apache | caddy)"},
+		{src: "CVE -windows", want: "cve -windows"},
+		{src: "CVE NOT windows", want: "cve -windows"},
+		{src: `CVE (nginx | "Apache httpd")`, want: `cve (nginx | "apache httpd")`},
+		{src: "«Go 1.24» release", want: `"go 1.24" release`},
+		{src: "a OR b c", want: "(a | b c)"},
+		{src: "rust-lang", want: "rust-lang"},
+		{src: "", wantErr: true},
+		{src: "   ", wantErr: true},
+		{src: "-windows", wantErr: true},
+		{src: "-a OR b", wantErr: true},
+		{src: "(nginx", wantErr: true},
+		{src: "nginx)", wantErr: true},
+		{src: "nginx OR", wantErr: true},
+		{src: "OR nginx", wantErr: true},
+		{src: "CVE NOT", wantErr: true},
+		{src: `"openssl`, wantErr: true},
+		{src: `CVE ""`, wantErr: true},
+		{src: "()", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.src, func(t *testing.T) {
+			expr, err := Parse(tt.src)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Parse(%q) error = %v, wantErr %v", tt.src, err, tt.wantErr)
+			}
+			if !tt.wantErr && expr.String() != tt.want {
+				t.Errorf("Parse(%q) = %s, want %s", tt.src, expr, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		expr string
+		text string
+		want bool
+	}{
+		{expr: "CVE openssl", text: "New CVE in OpenSSL 3.0", want: true},
+		{expr: "CVE openssl", text: "New CVE in LibreSSL", want: false},
+		{expr: "nginx OR apache", text: "Apache 2.4.62 released", want: true},
+		{expr: "nginx OR apache", text: "Caddy 2.8 released", want: false},
+		{expr: "CVE -windows", text: "CVE-2024-1 affects Linux", want: true},
+		{expr: "CVE -windows", text: "CVE-2024-1 affects Windows", want: false},
+		{expr: `"apache httpd"`, text: "Apache HTTPD 2.4", want: true},
+		{expr: `"apache httpd"`, text: "httpd from Apache", want: false},
+		{expr: "go", text: "Google released Go 1.24", want: true},
+		{expr: "go", text: "Google released Gemini", want: false},
+		{expr: "go", text: "golang, gopher", want: false},
+		{expr: "go", text: "(go)", want: true},
+		{expr: "rust", text: "trust rust", want: true},
+		{expr: "ядро linux", text: "Вышло новое Ядро Linux 6.12", want: true},
+		{expr: "ядро", text: "ядром", want: false},
+		{expr: "CVE (nginx | \"apache httpd\")", text: "CVE in nginx", want: true},
+		{expr: "CVE (nginx | \"apache httpd\")", text: "nginx 1.27 released", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr+"/"+tt.text, func(t *testing.T) {
+			expr, err := Parse(tt.expr)
+			if err != nil {
+				t.Fatalf("Parse(%q) error = %v", tt.expr, err)
+			}
+			if got := expr.Match(Normalize(tt.text)); got != tt.want {
+				t.Errorf("%s.Match(%q) = %v, want %v", expr, tt.text, got, tt.want)
+			}
+		})
+	}
+}
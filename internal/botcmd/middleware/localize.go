@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"context"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/speeddem0n/GoNewsBot/internal/botkit"
+	"github.com/speeddem0n/GoNewsBot/internal/i18n"
+)
+
+type LocaleResolver interface { // Интерфейс для связи с i18n.Resolver
+	Resolve(ctx context.Context, chatID int64, languageCode string) i18n.Locale
+}
+
+func Localize(resolver LocaleResolver) botkit.Middleware { // Middleware определяет язык ответов и сохраняет его в контекст
+	return func(next botkit.ViewFunc) botkit.ViewFunc {
+		return func(ctx context.Context, bot botkit.Messenger, update tgbotapi.Update) error {
+			var (
+				chatID       int64
+				languageCode string
+			)
+
+			if chat := update.FromChat(); chat != nil {
+				chatID = chat.ID
+			}
+			if user := update.SentFrom(); user != nil {
+				languageCode = user.LanguageCode
+			}
+
+			return next(i18n.WithLocale(ctx, resolver.Resolve(ctx, chatID, languageCode)), bot, update)
+		}
+	}
+}
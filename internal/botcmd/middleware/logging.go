@@ -0,0 +1,67 @@
+package middleware
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/sirupsen/logrus"
+	"github.com/speeddem0n/GoNewsBot/internal/botkit"
+)
+
+func Logging() botkit.Middleware { // Middleware логирует каждый обработанный апдейт и время его обработки
+	return func(next botkit.ViewFunc) botkit.ViewFunc {
+		return func(ctx context.Context, bot botkit.Messenger, update tgbotapi.Update) error {
+			start := time.Now()
+
+			err := next(ctx, bot, update)
+
+			entry := logrus.WithFields(logrus.Fields{
+				"command":  commandName(update),
+				"user_id":  userID(update),
+				"chat_id":  chatID(update),
+				"duration": time.Since(start),
+			})
+
+			if err != nil {
+				entry.WithError(err).Warn("update handled with error")
+				return err
+			}
+
+			entry.Info("update handled")
+
+			return nil
+		}
+	}
+}
+
+func commandName(update tgbotapi.Update) string { // Функция возвращает команду из апдейта, для обычных сообщений возвращает "message"
+	switch {
+	case update.Message != nil && update.Message.IsCommand():
+		return update.Message.Command()
+	case update.CallbackQuery != nil: // Для кнопок возвращаем префикс callback_data, например "callback:search"
+		prefix, _, _ := strings.Cut(update.CallbackQuery.Data, ":")
+		return "callback:" + prefix
+	case update.InlineQuery != nil:
+		return "inline"
+	default:
+		return "message"
+	}
+}
+
+func chatID(update tgbotapi.Update) int64 { // Функция возвращает ID чата, у кнопок под inline сообщениями чата нет
+	if chat := update.FromChat(); chat != nil {
+		return chat.ID
+	}
+
+	return 0
+}
+
+func userID(update tgbotapi.Update) int64 { // Функция возвращает ID пользователя отправившего апдейт
+	if user := update.SentFrom(); user != nil {
+		return user.ID
+	}
+
+	return 0
+}
@@ -0,0 +1,37 @@
+package middleware
+
+import (
+	"context"
+	"expvar"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/speeddem0n/GoNewsBot/internal/botkit"
+)
+
+var ( // Счетчики публикуются через expvar и доступны по адресу /debug/vars
+	commandCalls    = expvar.NewMap("bot_command_calls")       // Количество вызовов каждой команды
+	commandErrors   = expvar.NewMap("bot_command_errors")      // Количество ошибок каждой команды
+	commandDuration = expvar.NewMap("bot_command_duration_ms") // Суммарное время обработки каждой команды в миллисекундах
+)
+
+func Metrics() botkit.Middleware { // Middleware собирает количество вызовов, ошибок и время обработки команд
+	return func(next botkit.ViewFunc) botkit.ViewFunc {
+		return func(ctx context.Context, bot botkit.Messenger, update tgbotapi.Update) error {
+			var (
+				cmd   = commandName(update)
+				start = time.Now()
+			)
+
+			err := next(ctx, bot, update)
+
+			commandCalls.Add(cmd, 1)
+			commandDuration.Add(cmd, time.Since(start).Milliseconds())
+			if err != nil {
+				commandErrors.Add(cmd, 1)
+			}
+
+			return err
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package middleware
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/speeddem0n/GoNewsBot/internal/botkit"
+	"github.com/speeddem0n/GoNewsBot/internal/i18n"
+)
+
+type bucket struct { // Корзина токенов одного пользователя
+	tokens  float64
+	updated time.Time
+}
+
+type rateLimiter struct {
+	burst  float64 // Максимальное количество запросов подряд
+	refill float64 // Сколько токенов восстанавливается за секунду
+	mu     sync.Mutex
+	users  map[int64]*bucket
+}
+
+func RateLimit(limit int, interval time.Duration) botkit.Middleware { // Middleware ограничивает пользователя limit апдейтами за interval
+	limiter := &rateLimiter{
+		burst:  float64(limit),
+		refill: float64(limit) / interval.Seconds(),
+		users:  make(map[int64]*bucket),
+	}
+
+	return func(next botkit.ViewFunc) botkit.ViewFunc {
+		return func(ctx context.Context, bot botkit.Messenger, update tgbotapi.Update) error {
+			if limit <= 0 || interval <= 0 { // Ограничение выключено
+				return next(ctx, bot, update)
+			}
+
+			if limiter.allow(userID(update), time.Now()) {
+				return next(ctx, bot, update)
+			}
+
+			text := i18n.T(rateLimitLocale(update), botkit.TooManyRequestsMsg)
+
+			if update.InlineQuery != nil { // На inline запрос отвечаем пустым списком, сообщение отправить некуда
+				return bot.AnswerInline(tgbotapi.InlineConfig{InlineQueryID: update.InlineQuery.ID, Results: []any{}, IsPersonal: true})
+			}
+
+			if update.CallbackQuery != nil { // На нажатие кнопки отвечаем всплывающим уведомлением
+				return bot.AnswerCallback(tgbotapi.NewCallback(update.CallbackQuery.ID, text))
+			}
+
+			if _, err := bot.Send(tgbotapi.NewMessage(chatID(update), text)); err != nil {
+				return err
+			}
+
+			return nil
+		}
+	}
+}
+
+func rateLimitLocale(update tgbotapi.Update) i18n.Locale { // RateLimit стоит до Localize, чтобы лишние апдейты не читали язык чата из БД, поэтому отвечаем на языке из настроек телеграма
+	var languageCode string
+	if user := update.SentFrom(); user != nil {
+		languageCode = user.LanguageCode
+	}
+
+	locale, _ := i18n.Parse(languageCode)
+
+	return locale
+}
+
+func (l *rateLimiter) allow(userID int64, now time.Time) bool { // Метод забирает токен из корзины пользователя, возвращает false если токенов нет
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	b, ok := l.users[userID]
+	if !ok {
+		if len(l.users) >= 10000 { // Не даем мапе бесконечно расти, удаляем полностью восстановившиеся корзины
+			l.cleanup(now)
+		}
+
+		b = &bucket{tokens: l.burst, updated: now}
+		l.users[userID] = b
+	}
+
+	b.tokens = min(l.burst, b.tokens+now.Sub(b.updated).Seconds()*l.refill) // Восстанавливаем токены за прошедшее время
+	b.updated = now
+
+	if b.tokens < 1 {
+		return false
+	}
+
+	b.tokens--
+
+	return true
+}
+
+func (l *rateLimiter) cleanup(now time.Time) {
+	for id, b := range l.users {
+		if b.tokens+now.Sub(b.updated).Seconds()*l.refill >= l.burst {
+			delete(l.users, id)
+		}
+	}
+}
@@ -0,0 +1,26 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/sirupsen/logrus"
+	"github.com/speeddem0n/GoNewsBot/internal/botkit"
+)
+
+func Recover() botkit.Middleware { // Middleware перехватывает панику во ViewFunc и превращает ее в ошибку
+	return func(next botkit.ViewFunc) botkit.ViewFunc {
+		return func(ctx context.Context, bot botkit.Messenger, update tgbotapi.Update) (err error) {
+			defer func() {
+				if p := recover(); p != nil {
+					logrus.Errorf("panic recovered: %v\n%s", p, string(debug.Stack()))
+					err = fmt.Errorf("panic in view: %v", p) // Ошибка вернется в бота и пользователь получит сообщение об ошибке
+				}
+			}()
+
+			return next(ctx, bot, update)
+		}
+	}
+}
@@ -0,0 +1,24 @@
+package middleware
+
+import (
+	"context"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/speeddem0n/GoNewsBot/internal/botkit"
+)
+
+func Timeout(timeout time.Duration) botkit.Middleware { // Middleware ограничивает время обработки апдейта
+	return func(next botkit.ViewFunc) botkit.ViewFunc {
+		return func(ctx context.Context, bot botkit.Messenger, update tgbotapi.Update) error {
+			if timeout <= 0 { // Нулевой таймаут означает что время обработки не ограничено
+				return next(ctx, bot, update)
+			}
+
+			ctx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+
+			return next(ctx, bot, update)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package botcmd
+
+import (
+	"errors"
+	"strconv"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/samber/lo"
+	"github.com/speeddem0n/GoNewsBot/internal/botkit"
+	"github.com/speeddem0n/GoNewsBot/internal/i18n"
+)
+
+const (
+	dateLayout     = "02.01.2006"           // Формат даты публикации статьи в списках
+	dateTimeLayout = "02.01.2006 15:04 UTC" // Время в БД хранится в UTC
+)
+
+func pageKeyboard(locale i18n.Locale, page int, hasNext bool, callbackData func(page int) string, rows ...[]tgbotapi.InlineKeyboardButton) *tgbotapi.InlineKeyboardMarkup { // Функция создает кнопки листания страниц под рядами rows, возвращает nil если кнопок нет
+	var buttons []tgbotapi.InlineKeyboardButton
+
+	if page > 0 {
+		buttons = append(buttons, tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, botkit.PrevPageButton), callbackData(page-1)))
+	}
+	if hasNext {
+		buttons = append(buttons, tgbotapi.NewInlineKeyboardButtonData(i18n.T(locale, botkit.NextPageButton), callbackData(page+1)))
+	}
+
+	if len(buttons) > 0 {
+		rows = append(rows, buttons)
+	}
+
+	rows = lo.Filter(rows, func(row []tgbotapi.InlineKeyboardButton, _ int) bool { return len(row) > 0 })
+	if len(rows) == 0 {
+		return nil
+	}
+
+	keyboard := tgbotapi.NewInlineKeyboardMarkup(rows...)
+
+	return &keyboard
+}
+
+func pageHasNext[T any](items []T, pageSize int) ([]T, bool) { // Функция отрезает лишний элемент, который запрашивается чтобы узнать есть ли следующая страница
+	if len(items) > pageSize {
+		return items[:pageSize], true
+	}
+
+	return items, false
+}
+
+func callbackNumbers(update tgbotapi.Update, count int) ([]int64, bool) { // Функция разбирает числовые данные кнопки, например ID источника и номер страницы
+	payload := botkit.CallbackPayload(update)
+	if len(payload) != count {
+		return nil, false
+	}
+
+	numbers := make([]int64, 0, count)
+
+	for _, part := range payload {
+		n, err := strconv.ParseInt(part, 10, 64)
+		if err != nil || n < 0 {
+			return nil, false
+		}
+		numbers = append(numbers, n)
+	}
+
+	return numbers, true
+}
+
+func sendPage(bot botkit.Messenger, chatID int64, text string, keyboard *tgbotapi.InlineKeyboardMarkup, parseMode string) error { // Функция отправляет первую страницу списка
+	msg := tgbotapi.NewMessage(chatID, text)
+	msg.ParseMode = parseMode
+	msg.DisableWebPagePreview = true // Превью первой ссылки в списке только мешает
+	if keyboard != nil {
+		msg.ReplyMarkup = keyboard
+	}
+
+	if _, err := bot.Send(msg); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func editPage(bot botkit.Messenger, callback *tgbotapi.CallbackQuery, text string, keyboard *tgbotapi.InlineKeyboardMarkup, parseMode string) error { // Функция заменяет сообщение со списком другой страницей и отвечает на нажатие кнопки
+	edit := tgbotapi.NewEditMessageText(callback.Message.Chat.ID, callback.Message.MessageID, text)
+	edit.ParseMode = parseMode
+	edit.DisableWebPagePreview = true
+	edit.ReplyMarkup = keyboard
+
+	if err := bot.Edit(edit); err != nil {
+		return err
+	}
+
+	return bot.AnswerCallback(tgbotapi.NewCallback(callback.ID, ""))
+}
+
+func answerPageError(bot botkit.Messenger, callback *tgbotapi.CallbackQuery, locale i18n.Locale, err error) error { // Функция показывает ошибку i18n.Error всплывающим уведомлением, остальные ошибки возвращаются как есть
+	var userErr *i18n.Error
+	if !errors.As(err, &userErr) {
+		return err
+	}
+
+	return bot.AnswerCallback(tgbotapi.NewCallback(callback.ID, i18n.ErrorText(locale, err)))
+}
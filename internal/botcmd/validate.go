@@ -0,0 +1,58 @@
+package botcmd
+
+import (
+	"net/url"
+	"strconv"
+
+	"github.com/sirupsen/logrus"
+	"github.com/speeddem0n/GoNewsBot/internal/botkit"
+	"github.com/speeddem0n/GoNewsBot/internal/i18n"
+)
+
+func invalidArgsError[T any](locale i18n.Locale, cmd string, err error) error { // Функция формирует для пользователя ошибку с описанием аргументов команды
+	logrus.Debugf("invalid arguments for command %q: %v", cmd, err)
+
+	return i18n.NewError(botkit.InvalidArgsMsg, botkit.ArgsUsage[T](locale, cmd))
+}
+
+func validateNotEmpty(answer string) error { // Проверка что ответ пользователя не пустой
+	if answer == "" {
+		return i18n.NewError(botkit.EmptyAnswerMsg)
+	}
+
+	return nil
+}
+
+func validateFeedURL(answer string) error { // Проверка что ответ пользователя является http(s) ссылкой
+	u, err := url.ParseRequestURI(answer)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return i18n.NewError(botkit.InvalidSourceURLMsg)
+	}
+
+	return nil
+}
+
+func validateSourceID(answer string) error { // Проверка что ответ пользователя является ID источника
+	if _, err := parseSourceID(answer); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func validateOptionalFeedURL(answer string) error { // Проверка ссылки, которую можно оставить без изменений
+	if answer == botkit.KeepCurrentValue {
+		return nil
+	}
+
+	return validateFeedURL(answer)
+}
+
+func parseSourceID(answer string) (int64, error) { // Функция для получения ID источника из ответа пользователя
+	id, err := strconv.ParseInt(answer, 10, 64)
+	if err != nil || id <= 0 {
+		return 0, i18n.NewError(botkit.InvalidSourceIDMsg)
+	}
+
+	return id, nil
+}
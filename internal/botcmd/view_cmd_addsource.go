@@ -2,11 +2,10 @@ package botcmd
 
 import (
 	"context"
-	"fmt"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 	"github.com/speeddem0n/GoNewsBot/internal/botkit"
-	"github.com/speeddem0n/GoNewsBot/internal/botkit/markup"
+	"github.com/speeddem0n/GoNewsBot/internal/i18n"
 	"github.com/speeddem0n/GoNewsBot/internal/models"
 )
 
@@ -14,44 +13,63 @@ type SourceStorage interface { // Интерфейс для работы со с
 	Add(ctx context.Context, source models.Source) (int64, error)
 }
 
-func ViewCmdAddSource(storage SourceStorage) botkit.ViewFunc { // View для добавления источника
-	type addSourceArgs struct {
-		Name string `json:"name"`
-		URL  string `json:"url"`
-	}
-	return func(ctx context.Context, bot *tgbotapi.BotAPI, update tgbotapi.Update) error {
-		args, err := botkit.ParseJSON[addSourceArgs](update.Message.CommandArguments()) // парсим JSON объект из аргументов комманды в тип ddSourceArgs
-		if err != nil {
-			errReply := tgbotapi.NewMessage(update.Message.Chat.ID, markup.EscapeForMarkdown(botkit.InvalidAddInput))
-			errReply.ParseMode = "MarkdownV2"
-			if _, err := bot.Send(errReply); err != nil {
-				return err
+type addSourceArgs struct {
+	Name string `arg:"name" help:"args.source_name"`
+	URL  string `arg:"url,url" help:"args.source_url"`
+}
+
+var CmdAddSource = botkit.Command{ // Описание команды add
+	Name:        "add",
+	Description: botkit.CmdAddDescription,
+	Usage:       func(locale i18n.Locale) string { return botkit.ArgsUsage[addSourceArgs](locale, "add") },
+	Role:        models.RoleEditor,
+}
+
+func ViewCmdAddSource(storage SourceStorage, conversations *botkit.ConversationManager) botkit.ViewFunc { // View для добавления источника
+	return conversations.Begin(&botkit.Conversation{
+		Steps: []botkit.ConversationStep{ // Сначала спрашиваем ссылку на ленту, потом имя источника
+			{Key: "url", Prompt: botkit.AskSourceURLMsg, Validate: validateFeedURL},
+			{Key: "name", Prompt: botkit.AskSourceNameMsg, Validate: validateNotEmpty},
+		},
+		Prefill: func(ctx context.Context, rawArgs string) (botkit.ConversationAnswers, error) {
+			args, err := botkit.ParseArgs[addSourceArgs](rawArgs) // парсим аргументы комманды в тип addSourceArgs
+			if err != nil {
+				return nil, invalidArgsError[addSourceArgs](i18n.FromContext(ctx), CmdAddSource.Name, err)
 			}
-			return err
-		}
 
-		source := models.Source{ // Заполняем модель источника данными из args
-			Name:    args.Name,
-			FeedURL: args.URL,
-		}
+			answers := make(botkit.ConversationAnswers)
+			if args.URL != "" {
+				answers["url"] = args.URL
+			}
+			if args.Name != "" {
+				answers["name"] = args.Name
+			}
 
-		sourceID, err := storage.Add(ctx, source)
-		if err != nil {
-			return err
-		}
+			return answers, nil
+		},
+		Done: func(ctx context.Context, bot botkit.Messenger, update tgbotapi.Update, answers botkit.ConversationAnswers) error {
+			source := models.Source{ // Заполняем модель источника ответами пользователя
+				Name:    answers["name"],
+				FeedURL: answers["url"],
+			}
 
-		var (
-			msgText = fmt.Sprintf("Источник добавлен с ID: `%d`\\. Используйте этот ID для управления источником\\.", sourceID) // Сообщение для пользователя
-			reply   = tgbotapi.NewMessage(update.Message.Chat.ID, msgText)
-		)
+			sourceID, err := storage.Add(ctx, source)
+			if err != nil {
+				return err
+			}
 
-		reply.ParseMode = "MarkdownV2"
+			var (
+				msgText = i18n.T(i18n.FromContext(ctx), botkit.SourceAddedMsg, sourceID) // Сообщение для пользователя
+				reply   = tgbotapi.NewMessage(update.Message.Chat.ID, msgText)
+			)
 
-		if _, err := bot.Send(reply); err != nil {
-			return err
-		}
+			reply.ParseMode = "MarkdownV2"
 
-		return nil
-	}
+			if _, err := bot.Send(reply); err != nil {
+				return err
+			}
 
+			return nil
+		},
+	})
 }
@@ -0,0 +1,105 @@
+package botcmd
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/speeddem0n/GoNewsBot/internal/botkit"
+	"github.com/speeddem0n/GoNewsBot/internal/botkit/botkittest"
+	"github.com/speeddem0n/GoNewsBot/internal/i18n"
+	"github.com/speeddem0n/GoNewsBot/internal/models"
+)
+
+type fakeSources []models.Source
+
+func (s *fakeSources) Add(ctx context.Context, source models.Source) (int64, error) {
+	*s = append(*s, source)
+	return int64(len(*s)), nil
+}
+
+func TestViewCmdAddSource(t *testing.T) {
+	const userID = 10
+
+	tests := []struct {
+		name      string
+		messages  []string
+		want      []models.Source
+		wantReply string
+	}{
+		{
+			name:      "arguments",
+			messages:  []string{`/add "Go Blog" https://go.dev/blog/feed.atom`},
+			want:      []models.Source{{Name: "Go Blog", FeedURL: "https://go.dev/blog/feed.atom"}},
+			wantReply: i18n.T(i18n.Russian, botkit.SourceAddedMsg, 1),
+		},
+		{
+			name:      "conversation",
+			messages:  []string{"/add", "https://go.dev/blog/feed.atom", "Go Blog"},
+			want:      []models.Source{{Name: "Go Blog", FeedURL: "https://go.dev/blog/feed.atom"}},
+			wantReply: i18n.T(i18n.Russian, botkit.SourceAddedMsg, 1),
+		},
+		{
+			name:      "url from arguments, name asked",
+			messages:  []string{"/add https://go.dev/blog/feed.atom", "Go Blog"},
+			want:      []models.Source{{Name: "Go Blog", FeedURL: "https://go.dev/blog/feed.atom"}},
+			wantReply: i18n.T(i18n.Russian, botkit.SourceAddedMsg, 1),
+		},
+		{
+			name:      "invalid url is asked again",
+			messages:  []string{"/add", "go.dev"},
+			wantReply: i18n.T(i18n.Russian, botkit.InvalidSourceURLMsg) + "\n\n" + i18n.T(i18n.Russian, botkit.AskSourceURLMsg),
+		},
+		{
+			name:      "invalid url in arguments",
+			messages:  []string{"/add Go go.dev"},
+			wantReply: i18n.T(i18n.Russian, botkit.InvalidSourceURLMsg),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				messenger     = botkittest.NewFakeMessenger()
+				conversations = botkit.NewConversationManager(time.Minute)
+				sources       fakeSources
+				view          = ViewCmdAddSource(&sources, conversations)
+				ctx           = i18n.WithLocale(context.Background(), i18n.Russian)
+			)
+
+			for _, text := range tt.messages {
+				update := botkittest.MessageUpdate(userID, userID, text)
+
+				if strings.HasPrefix(text, "/") {
+					if err := view(ctx, messenger, update); err != nil {
+						t.Fatalf("view(%q) error = %v", text, err)
+					}
+					continue
+				}
+
+				handled, err := conversations.Handle(ctx, messenger, update)
+				if err != nil {
+					t.Fatalf("Handle(%q) error = %v", text, err)
+				}
+				if !handled {
+					t.Fatalf("Handle(%q) found no conversation", text)
+				}
+			}
+
+			if len(sources) != len(tt.want) {
+				t.Fatalf("added sources = %+v, want %+v", sources, tt.want)
+			}
+			for i := range tt.want {
+				if sources[i] != tt.want[i] {
+					t.Errorf("added source = %+v, want %+v", sources[i], tt.want[i])
+				}
+			}
+
+			reply, ok := messenger.LastSent()
+			if !ok || reply.Text != tt.wantReply {
+				t.Errorf("reply = %q, want %q", reply.Text, tt.wantReply)
+			}
+		})
+	}
+}
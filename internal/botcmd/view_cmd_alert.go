@@ -0,0 +1,138 @@
+package botcmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/samber/lo"
+	"github.com/speeddem0n/GoNewsBot/internal/alert"
+	"github.com/speeddem0n/GoNewsBot/internal/botkit"
+	"github.com/speeddem0n/GoNewsBot/internal/i18n"
+	"github.com/speeddem0n/GoNewsBot/internal/models"
+)
+
+type AlertStorage interface { // Интерфейс для работы со слоем storage/alert.go
+	Add(ctx context.Context, alert models.Alert) (int64, error)
+	UserAlerts(ctx context.Context, userID int64) ([]models.Alert, error)
+	Remove(ctx context.Context, userID, id int64) (bool, error)
+}
+
+const (
+	maxAlertsPerUser = 20  // Сколько алертов может быть у одного пользователя
+	maxAlertLength   = 255 // Максимальная длина выражения, ограничена размером колонки в БД
+)
+
+type alertRemoveArgs struct {
+	ID int64 `arg:"id,required" help:"args.alert_id"`
+}
+
+var CmdAlert = botkit.Command{ // Описание команды alert
+	Name:        "alert",
+	Description: botkit.CmdAlertDescription,
+	Usage:       func(locale i18n.Locale) string { return i18n.T(locale, botkit.AlertUsageMsg) },
+}
+
+func ViewCmdAlert(alerts AlertStorage) botkit.ViewFunc { // View для управления алертами: /alert add, /alert list, /alert remove
+	return func(ctx context.Context, bot botkit.Messenger, update tgbotapi.Update) error {
+		var (
+			chatID = update.Message.Chat.ID
+			locale = i18n.FromContext(ctx)
+		)
+
+		if !update.Message.Chat.IsPrivate() { // Уведомления приходят в личные сообщения
+			return replyText(bot, chatID, i18n.T(locale, botkit.AlertPrivateOnlyMsg))
+		}
+
+		// Выражение разбирается пакетом alert, поэтому аргументы берутся как есть, без botkit.ParseArgs
+		subcommand, rest := splitSubcommand(update.Message.CommandArguments())
+		userID := update.Message.From.ID
+
+		switch subcommand {
+		case "add":
+			return addAlert(ctx, bot, chatID, userID, alerts, rest)
+		case "list", "ls":
+			return listAlerts(ctx, bot, chatID, userID, alerts)
+		case "remove", "rm", "delete":
+			args, err := botkit.ParseArgs[alertRemoveArgs](rest)
+			if err != nil || args.ID <= 0 {
+				return replyText(bot, chatID, i18n.T(locale, botkit.AlertUsageMsg))
+			}
+
+			removed, err := alerts.Remove(ctx, userID, args.ID)
+			if err != nil {
+				return err
+			}
+			if !removed {
+				return replyText(bot, chatID, i18n.T(locale, botkit.AlertNotFoundMsg, args.ID))
+			}
+
+			return replyText(bot, chatID, i18n.T(locale, botkit.AlertRemovedMsg, args.ID))
+		default:
+			return replyText(bot, chatID, i18n.T(locale, botkit.AlertUsageMsg))
+		}
+	}
+}
+
+func addAlert(ctx context.Context, bot botkit.Messenger, chatID, userID int64, alerts AlertStorage, expression string) error { // Функция проверяет выражение и сохраняет алерт
+	locale := i18n.FromContext(ctx)
+
+	if utf8.RuneCountInString(expression) > maxAlertLength {
+		return replyText(bot, chatID, i18n.T(locale, botkit.AlertTooLongMsg, maxAlertLength))
+	}
+
+	if _, err := alert.Parse(expression); err != nil {
+		return replyText(bot, chatID, i18n.T(locale, botkit.AlertInvalidMsg, err, i18n.T(locale, botkit.AlertUsageMsg)))
+	}
+
+	existing, err := alerts.UserAlerts(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if len(existing) >= maxAlertsPerUser {
+		return replyText(bot, chatID, i18n.T(locale, botkit.AlertLimitMsg, len(existing)))
+	}
+
+	id, err := alerts.Add(ctx, models.Alert{
+		UserID:     userID,
+		Expression: expression,
+	})
+	if err != nil {
+		return err
+	}
+
+	return replyText(bot, chatID, i18n.T(locale, botkit.AlertAddedMsg, id, expression))
+}
+
+func listAlerts(ctx context.Context, bot botkit.Messenger, chatID, userID int64, alerts AlertStorage) error { // Функция отправляет пользователю список его алертов
+	locale := i18n.FromContext(ctx)
+
+	userAlerts, err := alerts.UserAlerts(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if len(userAlerts) == 0 {
+		return replyText(bot, chatID, i18n.T(locale, botkit.AlertListEmptyMsg))
+	}
+
+	lines := lo.Map(userAlerts, func(a models.Alert, _ int) string {
+		return fmt.Sprintf("%d. %s", a.ID, a.Expression)
+	})
+
+	return replyText(bot, chatID, i18n.T(locale, botkit.AlertListMsg, strings.Join(lines, "\n")))
+}
+
+func splitSubcommand(args string) (string, string) { // Функция отделяет подкоманду от остальных аргументов
+	args = strings.TrimSpace(args)
+
+	i := strings.IndexFunc(args, unicode.IsSpace)
+	if i < 0 {
+		return strings.ToLower(args), ""
+	}
+
+	return strings.ToLower(args[:i]), strings.TrimSpace(args[i:])
+}
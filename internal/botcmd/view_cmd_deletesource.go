@@ -2,11 +2,11 @@ package botcmd
 
 import (
 	"context"
-	"fmt"
+	"strconv"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 	"github.com/speeddem0n/GoNewsBot/internal/botkit"
-	"github.com/speeddem0n/GoNewsBot/internal/botkit/markup"
+	"github.com/speeddem0n/GoNewsBot/internal/i18n"
 	"github.com/speeddem0n/GoNewsBot/internal/models"
 )
 
@@ -14,41 +14,61 @@ type SourceDeleter interface {
 	Delete(ctx context.Context, id int64) error
 }
 
-func ViewCmdDelete(deleter SourceDeleter) botkit.ViewFunc {
-	type deleteSourceArgs struct {
-		ID int64 `json:"id"`
-	}
-
-	return func(ctx context.Context, bot *tgbotapi.BotAPI, update tgbotapi.Update) error {
-		args, err := botkit.ParseJSON[deleteSourceArgs](update.Message.CommandArguments())
-		if err != nil {
-			errReply := tgbotapi.NewMessage(update.Message.Chat.ID, markup.EscapeForMarkdown(botkit.InvalidDeleteInput))
-			errReply.ParseMode = "MarkdownV2"
-			if _, err := bot.Send(errReply); err != nil {
+type deleteSourceArgs struct {
+	ID int64 `arg:"id" help:"args.source_id"`
+}
+
+var CmdDeleteSource = botkit.Command{ // Описание команды delete
+	Name:        "delete",
+	Description: botkit.CmdDeleteDescription,
+	Usage:       func(locale i18n.Locale) string { return botkit.ArgsUsage[deleteSourceArgs](locale, "delete") },
+	Role:        models.RoleEditor,
+}
+
+func ViewCmdDelete(deleter SourceDeleter, conversations *botkit.ConversationManager) botkit.ViewFunc {
+	return conversations.Begin(&botkit.Conversation{
+		Steps: []botkit.ConversationStep{
+			{Key: "id", Prompt: botkit.AskSourceIDMsg, Validate: validateSourceID},
+		},
+		Prefill: func(ctx context.Context, rawArgs string) (botkit.ConversationAnswers, error) {
+			args, err := botkit.ParseArgs[deleteSourceArgs](rawArgs)
+			if err != nil {
+				return nil, invalidArgsError[deleteSourceArgs](i18n.FromContext(ctx), CmdDeleteSource.Name, err)
+			}
+
+			answers := make(botkit.ConversationAnswers)
+			if args.ID != 0 {
+				answers["id"] = strconv.FormatInt(args.ID, 10)
+			}
+
+			return answers, nil
+		},
+		Done: func(ctx context.Context, bot botkit.Messenger, update tgbotapi.Update, answers botkit.ConversationAnswers) error {
+			id, err := parseSourceID(answers["id"])
+			if err != nil {
 				return err
 			}
-			return err
-		}
 
-		source := models.Source{
-			ID: args.ID,
-		}
+			source := models.Source{
+				ID: id,
+			}
 
-		if err = deleter.Delete(ctx, source.ID); err != nil {
-			return err
-		}
+			if err = deleter.Delete(ctx, source.ID); err != nil {
+				return err
+			}
 
-		var (
-			msgText = fmt.Sprintf("Источник удален с ID: `%d`\\.", source.ID) // Сообщение для пользователя
-			reply   = tgbotapi.NewMessage(update.Message.Chat.ID, msgText)
-		)
+			var (
+				msgText = i18n.T(i18n.FromContext(ctx), botkit.SourceDeletedMsg, source.ID) // Сообщение для пользователя
+				reply   = tgbotapi.NewMessage(update.Message.Chat.ID, msgText)
+			)
 
-		reply.ParseMode = "MarkdownV2"
+			reply.ParseMode = "MarkdownV2"
 
-		if _, err := bot.Send(reply); err != nil {
-			return err
-		}
+			if _, err := bot.Send(reply); err != nil {
+				return err
+			}
 
-		return nil
-	}
+			return nil
+		},
+	})
 }
@@ -0,0 +1,105 @@
+package botcmd
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strconv"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/speeddem0n/GoNewsBot/internal/botkit"
+	"github.com/speeddem0n/GoNewsBot/internal/i18n"
+	"github.com/speeddem0n/GoNewsBot/internal/models"
+)
+
+type SourceEditor interface { // Интерфейс для работы со слоем storage
+	SourceByID(ctx context.Context, id int64) (*models.Source, error)
+	Update(ctx context.Context, source models.Source) error
+}
+
+type editSourceArgs struct {
+	ID   int64  `arg:"id" help:"args.source_id"`
+	Name string `arg:"name" help:"args.source_new_name"`
+	URL  string `arg:"url,url" help:"args.source_new_url"`
+}
+
+var CmdEditSource = botkit.Command{ // Описание команды edit
+	Name:        "edit",
+	Description: botkit.CmdEditDescription,
+	Usage:       func(locale i18n.Locale) string { return botkit.ArgsUsage[editSourceArgs](locale, "edit") },
+	Role:        models.RoleEditor,
+}
+
+func ViewCmdEditSource(editor SourceEditor, conversations *botkit.ConversationManager) botkit.ViewFunc { // View для изменения имени или ссылки источника
+	return conversations.Begin(&botkit.Conversation{
+		Steps: []botkit.ConversationStep{
+			{Key: "id", Prompt: botkit.AskSourceIDMsg, Validate: validateSourceID},
+			{Key: "name", Prompt: botkit.AskNewSourceNameMsg, Validate: validateNotEmpty},
+			{Key: "url", Prompt: botkit.AskNewSourceURLMsg, Validate: validateOptionalFeedURL},
+		},
+		Prefill: func(ctx context.Context, rawArgs string) (botkit.ConversationAnswers, error) {
+			args, err := botkit.ParseArgs[editSourceArgs](rawArgs)
+			if err != nil {
+				return nil, invalidArgsError[editSourceArgs](i18n.FromContext(ctx), CmdEditSource.Name, err)
+			}
+
+			answers := make(botkit.ConversationAnswers)
+			if args.ID != 0 {
+				answers["id"] = strconv.FormatInt(args.ID, 10)
+			}
+			if args.Name != "" || args.URL != "" { // Если передано хотя бы одно поле, второе оставляем без изменений
+				answers["name"] = botkit.KeepCurrentValue
+				answers["url"] = botkit.KeepCurrentValue
+			}
+			if args.Name != "" {
+				answers["name"] = args.Name
+			}
+			if args.URL != "" {
+				answers["url"] = args.URL
+			}
+
+			return answers, nil
+		},
+		Done: func(ctx context.Context, bot botkit.Messenger, update tgbotapi.Update, answers botkit.ConversationAnswers) error {
+			locale := i18n.FromContext(ctx)
+
+			id, err := parseSourceID(answers["id"])
+			if err != nil {
+				return err
+			}
+
+			source, err := editor.SourceByID(ctx, id)
+			if err != nil {
+				if errors.Is(err, sql.ErrNoRows) { // Источника с таким ID нет
+					_, err := bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(locale, botkit.SourceNotFoundMsg)))
+					return err
+				}
+				return err
+			}
+
+			if name := answers["name"]; name != botkit.KeepCurrentValue {
+				source.Name = name
+			}
+			if feedURL := answers["url"]; feedURL != botkit.KeepCurrentValue {
+				source.FeedURL = feedURL
+			}
+
+			if err := editor.Update(ctx, *source); err != nil {
+				return err
+			}
+
+			var (
+				msgText = i18n.T(locale, botkit.SourceEditedMsg, source.ID, formatSource(locale, *source)) // Сообщение для пользователя
+				reply   = tgbotapi.NewMessage(update.Message.Chat.ID, msgText)
+			)
+
+			reply.ParseMode = "MarkdownV2"
+
+			if _, err := bot.Send(reply); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	})
+}
@@ -0,0 +1,105 @@
+package botcmd
+
+import (
+	"context"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/speeddem0n/GoNewsBot/internal/botkit"
+	"github.com/speeddem0n/GoNewsBot/internal/i18n"
+	"github.com/speeddem0n/GoNewsBot/internal/models"
+)
+
+type RoleManager interface { // Интерфейс для связи с пакетом roles
+	RoleOf(ctx context.Context, userID int64) (models.Role, error)
+	StoredRole(ctx context.Context, userID int64) (models.Role, error)
+	Grant(ctx context.Context, userID int64, role models.Role, grantedBy int64) error
+	Revoke(ctx context.Context, userID int64) error
+}
+
+type grantArgs struct {
+	Role string `arg:"role,required" help:"args.role"`
+	User int64  `arg:"user" help:"args.user"`
+}
+
+var CmdGrant = botkit.Command{ // Описание команды grant
+	Name:        "grant",
+	Description: botkit.CmdGrantDescription,
+	Usage:       func(locale i18n.Locale) string { return botkit.ArgsUsage[grantArgs](locale, "grant") },
+	Role:        models.RoleAdmin,
+}
+
+func ViewCmdGrant(roles RoleManager) botkit.ViewFunc { // View для выдачи роли пользователю
+	return func(ctx context.Context, bot botkit.Messenger, update tgbotapi.Update) error {
+		var (
+			chatID = update.Message.Chat.ID
+			locale = i18n.FromContext(ctx)
+		)
+
+		args, err := botkit.ParseArgs[grantArgs](update.Message.CommandArguments())
+		if err != nil {
+			return replyText(bot, chatID, i18n.ErrorText(locale, invalidArgsError[grantArgs](locale, CmdGrant.Name, err)))
+		}
+
+		role := models.Role(args.Role)
+		if !role.Grantable() {
+			return replyText(bot, chatID, i18n.T(locale, botkit.InvalidRoleMsg))
+		}
+
+		target, ok := targetUserID(update, args.User)
+		if !ok {
+			return replyText(bot, chatID, i18n.T(locale, botkit.GrantUserRequiredMsg))
+		}
+
+		allowed, err := canManageRoles(ctx, roles, update.Message.From.ID, target, role)
+		if err != nil {
+			return err
+		}
+		if !allowed {
+			return replyText(bot, chatID, i18n.T(locale, botkit.CantManageRoleMsg))
+		}
+
+		if err := roles.Grant(ctx, target, role, update.Message.From.ID); err != nil {
+			return err
+		}
+
+		return replyText(bot, chatID, i18n.T(locale, botkit.RoleGrantedMsg, target, role))
+	}
+}
+
+func canManageRoles(ctx context.Context, roles RoleManager, granterID, targetID int64, role models.Role) (bool, error) { // Функция проверяет может ли пользователь выдать роль role и заменить текущую роль пользователя
+	granterRole, err := roles.RoleOf(ctx, granterID)
+	if err != nil {
+		return false, err
+	}
+
+	currentRole, err := roles.StoredRole(ctx, targetID)
+	if err != nil {
+		return false, err
+	}
+
+	canManage := func(r models.Role) bool { // Владелец управляет любыми ролями, остальные только ролями ниже своей
+		return granterRole == models.RoleOwner || granterRole.Level() > r.Level()
+	}
+
+	return canManage(role) && canManage(currentRole), nil
+}
+
+func targetUserID(update tgbotapi.Update, argUser int64) (int64, bool) { // Функция возвращает ID пользователя из аргумента или из сообщения на которое ответили командой
+	if argUser > 0 {
+		return argUser, true
+	}
+
+	if reply := update.Message.ReplyToMessage; reply != nil && reply.From != nil {
+		return reply.From.ID, true
+	}
+
+	return 0, false
+}
+
+func replyText(bot botkit.Messenger, chatID int64, text string) error { // Функция для отправки ответа без разметки
+	if _, err := bot.Send(tgbotapi.NewMessage(chatID, text)); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,64 @@
+package botcmd
+
+import (
+	"context"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/speeddem0n/GoNewsBot/internal/botkit"
+	"github.com/speeddem0n/GoNewsBot/internal/i18n"
+	"github.com/speeddem0n/GoNewsBot/internal/models"
+)
+
+type CommandRegistry interface { // Интерфейс для получения списка зарегистрированных команд
+	Commands() []botkit.Command
+}
+
+type RoleResolver interface { // Интерфейс для связи с пакетом roles
+	RoleOf(ctx context.Context, userID int64) (models.Role, error)
+}
+
+var ( // Описание команд help, start и cancel
+	CmdHelp = botkit.Command{
+		Name:        "help",
+		Description: botkit.CmdHelpDescription,
+		Scopes:      []botkit.CommandScope{botkit.ScopePrivateChats, botkit.ScopeGroupChats, botkit.ScopeChatAdmins},
+	}
+	CmdStart = botkit.Command{ // Команда без описания не показывается в меню, телеграм отправляет ее при первом запуске бота
+		Name: "start",
+	}
+	CmdCancel = botkit.Command{
+		Name:        "cancel",
+		Description: botkit.CmdCancelDescription,
+	}
+)
+
+func ViewCmdHelp(registry CommandRegistry, roles RoleResolver) botkit.ViewFunc { // View со списком команд доступных пользователю
+	return func(ctx context.Context, bot botkit.Messenger, update tgbotapi.Update) error {
+		role, err := roles.RoleOf(ctx, update.Message.From.ID)
+		if err != nil {
+			return err
+		}
+
+		locale := i18n.FromContext(ctx)
+
+		lines := []string{i18n.T(locale, botkit.HelpHeaderMsg)}
+
+		for _, cmd := range registry.Commands() {
+			if cmd.Description == "" || !role.AtLeast(cmd.Role) { // Скрываем команды на которые у пользователя нет прав
+				continue
+			}
+
+			line := "/" + cmd.Name + " - " + i18n.T(locale, cmd.Description)
+			if cmd.Usage != nil {
+				line += "\n" + cmd.Usage(locale)
+			}
+
+			lines = append(lines, line)
+		}
+
+		lines = append(lines, i18n.T(locale, botkit.HelpJSONArgsMsg))
+
+		return replyText(bot, update.Message.Chat.ID, strings.Join(lines, "\n\n"))
+	}
+}
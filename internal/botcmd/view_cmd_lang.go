@@ -0,0 +1,131 @@
+package botcmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/samber/lo"
+	"github.com/speeddem0n/GoNewsBot/internal/botkit"
+	"github.com/speeddem0n/GoNewsBot/internal/i18n"
+	"github.com/speeddem0n/GoNewsBot/internal/models"
+)
+
+type ChatSettingsStorage interface { // Интерфейс для работы со слоем storage/chat_settings.go
+	SetChatLocale(ctx context.Context, chatID int64, locale string) error
+	ResetChatLocale(ctx context.Context, chatID int64) error
+}
+
+type langArgs struct {
+	Locale string `arg:"locale" help:"args.locale"`
+	Target string `arg:"target" help:"args.lang_target"`
+}
+
+const (
+	langAuto          = "auto"    // Сброс языка чата, бот использует язык из настроек телеграма
+	langTargetChannel = "channel" // Язык публикаций в канале
+)
+
+var CmdLang = botkit.Command{ // Описание команды lang
+	Name:        "lang",
+	Description: botkit.CmdLangDescription,
+	Usage:       func(locale i18n.Locale) string { return botkit.ArgsUsage[langArgs](locale, "lang") },
+	Scopes:      []botkit.CommandScope{botkit.ScopePrivateChats, botkit.ScopeGroupChats, botkit.ScopeChatAdmins},
+}
+
+func ViewCmdLang(settings ChatSettingsStorage, roles RoleResolver, channelID int64) botkit.ViewFunc { // View для выбора языка сообщений бота в чате или в канале
+	return func(ctx context.Context, bot botkit.Messenger, update tgbotapi.Update) error {
+		var (
+			chatID    = update.Message.Chat.ID
+			locale    = i18n.FromContext(ctx)
+			available = strings.Join(lo.Map(i18n.Locales(), func(l i18n.Locale, _ int) string { return string(l) }), ", ")
+		)
+
+		args, err := botkit.ParseArgs[langArgs](update.Message.CommandArguments())
+		if err != nil {
+			return replyText(bot, chatID, i18n.ErrorText(locale, invalidArgsError[langArgs](locale, CmdLang.Name, err)))
+		}
+
+		if args.Locale == "" { // Без аргументов показываем текущий язык
+			return replyText(bot, chatID, i18n.T(locale, botkit.LangCurrentMsg, locale, available))
+		}
+
+		if args.Target == langTargetChannel { // Язык канала меняют только администраторы
+			role, err := roles.RoleOf(ctx, update.Message.From.ID)
+			if err != nil {
+				return err
+			}
+			if !role.AtLeast(models.RoleAdmin) {
+				return replyText(bot, chatID, i18n.T(locale, botkit.AccessDeniedMsg))
+			}
+
+			newLocale, ok := i18n.Parse(args.Locale) // У канала нет пользователя, поэтому auto для него не поддерживается
+			if !ok {
+				return replyText(bot, chatID, i18n.T(locale, botkit.UnknownLangMsg, available))
+			}
+
+			if err := settings.SetChatLocale(ctx, channelID, string(newLocale)); err != nil {
+				return err
+			}
+
+			return replyText(bot, chatID, i18n.T(locale, botkit.ChannelLangChangedMsg, newLocale))
+		}
+
+		if args.Target != "" {
+			return replyText(bot, chatID, i18n.ErrorText(locale, invalidArgsError[langArgs](locale, CmdLang.Name, fmt.Errorf("unknown target %q", args.Target))))
+		}
+
+		allowed, err := canChangeChatLang(ctx, bot, roles, update.Message.Chat, update.Message.From.ID)
+		if err != nil {
+			return err
+		}
+		if !allowed {
+			return replyText(bot, chatID, i18n.T(locale, botkit.AccessDeniedMsg))
+		}
+
+		if strings.EqualFold(args.Locale, langAuto) {
+			if err := settings.ResetChatLocale(ctx, chatID); err != nil {
+				return err
+			}
+
+			locale, _ = i18n.Parse(update.Message.From.LanguageCode) // Отвечаем уже на языке пользователя
+
+			return replyText(bot, chatID, i18n.T(locale, botkit.LangResetMsg))
+		}
+
+		newLocale, ok := i18n.Parse(args.Locale)
+		if !ok {
+			return replyText(bot, chatID, i18n.T(locale, botkit.UnknownLangMsg, available))
+		}
+
+		if err := settings.SetChatLocale(ctx, chatID, string(newLocale)); err != nil {
+			return err
+		}
+
+		return replyText(bot, chatID, i18n.T(newLocale, botkit.LangChangedMsg, newLocale))
+	}
+}
+
+func canChangeChatLang(ctx context.Context, bot botkit.Messenger, roles RoleResolver, chat *tgbotapi.Chat, userID int64) (bool, error) { // В личных сообщениях язык меняет сам пользователь, в группах - администраторы бота или самой группы
+	if chat.IsPrivate() {
+		return true, nil
+	}
+
+	role, err := roles.RoleOf(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	if role.AtLeast(models.RoleAdmin) {
+		return true, nil
+	}
+
+	admins, err := bot.ChatAdministrators(chat.ID) // Запрос к телеграму только при смене языка, просмотр текущего языка доступен всем
+	if err != nil {
+		return false, err
+	}
+
+	return lo.ContainsBy(admins, func(member tgbotapi.ChatMember) bool {
+		return member.User != nil && member.User.ID == userID
+	}), nil
+}
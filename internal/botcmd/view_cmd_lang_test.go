@@ -0,0 +1,153 @@
+package botcmd
+
+import (
+	"context"
+	"testing"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"github.com/speeddem0n/GoNewsBot/internal/botkit"
+	"github.com/speeddem0n/GoNewsBot/internal/botkit/botkittest"
+	"github.com/speeddem0n/GoNewsBot/internal/i18n"
+	"github.com/speeddem0n/GoNewsBot/internal/models"
+)
+
+const (
+	testChannelID = -100500
+	testGroupID   = -42
+)
+
+type fakeRoles map[int64]models.Role
+
+func (r fakeRoles) RoleOf(ctx context.Context, userID int64) (models.Role, error) {
+	return r[userID], nil
+}
+
+type fakeChatSettings map[int64]string
+
+func (s fakeChatSettings) SetChatLocale(ctx context.Context, chatID int64, locale string) error {
+	s[chatID] = locale
+	return nil
+}
+
+func (s fakeChatSettings) ResetChatLocale(ctx context.Context, chatID int64) error {
+	delete(s, chatID)
+	return nil
+}
+
+func TestViewCmdLang(t *testing.T) {
+	const (
+		user       = 10
+		groupAdmin = 11
+		botAdmin   = 12
+	)
+
+	tests := []struct {
+		name       string
+		chatID     int64
+		userID     int64
+		text       string
+		wantLocale map[int64]string
+		wantReply  string
+	}{
+		{
+			name:       "private chat",
+			chatID:     user,
+			userID:     user,
+			text:       "/lang en",
+			wantLocale: map[int64]string{user: "en"},
+			wantReply:  i18n.T(i18n.English, botkit.LangChangedMsg, i18n.English),
+		},
+		{
+			name:       "group member is denied",
+			chatID:     testGroupID,
+			userID:     user,
+			text:       "/lang en",
+			wantLocale: map[int64]string{},
+			wantReply:  i18n.T(i18n.Russian, botkit.AccessDeniedMsg),
+		},
+		{
+			name:       "group member can not reset",
+			chatID:     testGroupID,
+			userID:     user,
+			text:       "/lang auto",
+			wantLocale: map[int64]string{testGroupID: "ru"},
+			wantReply:  i18n.T(i18n.Russian, botkit.AccessDeniedMsg),
+		},
+		{
+			name:       "group administrator",
+			chatID:     testGroupID,
+			userID:     groupAdmin,
+			text:       "/lang en",
+			wantLocale: map[int64]string{testGroupID: "en"},
+			wantReply:  i18n.T(i18n.English, botkit.LangChangedMsg, i18n.English),
+		},
+		{
+			name:       "bot administrator",
+			chatID:     testGroupID,
+			userID:     botAdmin,
+			text:       "/lang en",
+			wantLocale: map[int64]string{testGroupID: "en"},
+			wantReply:  i18n.T(i18n.English, botkit.LangChangedMsg, i18n.English),
+		},
+		{
+			name:       "channel by group administrator is denied",
+			chatID:     testGroupID,
+			userID:     groupAdmin,
+			text:       "/lang en channel",
+			wantLocale: map[int64]string{},
+			wantReply:  i18n.T(i18n.Russian, botkit.AccessDeniedMsg),
+		},
+		{
+			name:       "channel by bot administrator",
+			chatID:     botAdmin,
+			userID:     botAdmin,
+			text:       "/lang en channel",
+			wantLocale: map[int64]string{testChannelID: "en"},
+			wantReply:  i18n.T(i18n.Russian, botkit.ChannelLangChangedMsg, i18n.English),
+		},
+		{
+			name:      "current language is shown to everyone",
+			chatID:    testGroupID,
+			userID:    user,
+			text:      "/lang",
+			wantReply: i18n.T(i18n.Russian, botkit.LangCurrentMsg, i18n.Russian, "ru, en"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			messenger := botkittest.NewFakeMessenger()
+			messenger.SetAdministrators(testGroupID, tgbotapi.ChatMember{User: &tgbotapi.User{ID: groupAdmin}, Status: "administrator"})
+
+			settings := fakeChatSettings{}
+			if tt.text == "/lang auto" {
+				settings[testGroupID] = "ru"
+			}
+
+			view := ViewCmdLang(settings, fakeRoles{botAdmin: models.RoleAdmin}, testChannelID)
+			ctx := i18n.WithLocale(context.Background(), i18n.Russian)
+
+			if err := view(ctx, messenger, botkittest.MessageUpdate(tt.chatID, tt.userID, tt.text)); err != nil {
+				t.Fatalf("view() error = %v", err)
+			}
+
+			reply, ok := messenger.LastSent()
+			if !ok {
+				t.Fatal("view() sent nothing")
+			}
+			if reply.Text != tt.wantReply {
+				t.Errorf("reply = %q, want %q", reply.Text, tt.wantReply)
+			}
+
+			if tt.wantLocale != nil && len(settings) != len(tt.wantLocale) {
+				t.Fatalf("chat locales = %v, want %v", settings, tt.wantLocale)
+			}
+			for chatID, locale := range tt.wantLocale {
+				if settings[chatID] != locale {
+					t.Errorf("chat %d locale = %q, want %q", chatID, settings[chatID], locale)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package botcmd
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/speeddem0n/GoNewsBot/internal/botkit"
+	"github.com/speeddem0n/GoNewsBot/internal/i18n"
+	"github.com/speeddem0n/GoNewsBot/internal/models"
+)
+
+type LatestArticles interface { // Интерфейс для работы со слоем storage/article.go
+	Latest(ctx context.Context, limit, offset uint64) ([]models.Article, error)
+}
+
+const (
+	defaultLatestCount = 10       // Сколько статей показывает /latest без аргументов
+	maxLatestCount     = 30       // Больше статей не помещается в одно сообщение
+	LatestCallback     = "latest" // Префикс callback_data кнопок листания, данные кнопки: количество статей на странице и номер страницы
+)
+
+type latestArgs struct {
+	Count int `arg:"n" help:"args.latest_count"`
+}
+
+var CmdLatest = botkit.Command{ // Описание команды latest
+	Name:        "latest",
+	Description: botkit.CmdLatestDescription,
+	Usage:       func(locale i18n.Locale) string { return botkit.ArgsUsage[latestArgs](locale, "latest") },
+}
+
+func ViewCmdLatest(articles LatestArticles, sources SourceLister) botkit.ViewFunc { // View со статьями всех источников, начиная с самой свежей
+	return func(ctx context.Context, bot botkit.Messenger, update tgbotapi.Update) error {
+		var (
+			chatID = update.Message.Chat.ID
+			locale = i18n.FromContext(ctx)
+		)
+
+		args, err := botkit.ParseArgs[latestArgs](update.Message.CommandArguments())
+		if err != nil {
+			return replyUserError(bot, chatID, locale, invalidArgsError[latestArgs](locale, CmdLatest.Name, err))
+		}
+
+		count := args.Count
+		if count <= 0 {
+			count = defaultLatestCount
+		}
+
+		text, keyboard, err := latestPage(ctx, articles, sources, locale, min(count, maxLatestCount), 0)
+		if err != nil {
+			return err
+		}
+
+		return sendPage(bot, chatID, text, keyboard, "")
+	}
+}
+
+func ViewLatestPage(articles LatestArticles, sources SourceLister) botkit.ViewFunc { // View для кнопок листания /latest
+	return func(ctx context.Context, bot botkit.Messenger, update tgbotapi.Update) error {
+		callback := update.CallbackQuery
+
+		numbers, ok := callbackNumbers(update, 2) // Данные кнопки: количество статей на странице и номер страницы
+		if !ok || callback.Message == nil || numbers[0] == 0 {
+			return bot.AnswerCallback(tgbotapi.NewCallback(callback.ID, ""))
+		}
+
+		text, keyboard, err := latestPage(ctx, articles, sources, i18n.FromContext(ctx), min(int(numbers[0]), maxLatestCount), int(numbers[1]))
+		if err != nil {
+			return err
+		}
+
+		return editPage(bot, callback, text, keyboard, "")
+	}
+}
+
+func latestPage(ctx context.Context, articles LatestArticles, sources SourceLister, locale i18n.Locale, count, page int) (string, *tgbotapi.InlineKeyboardMarkup, error) { // Функция возвращает текст страницы последних статей с кнопками листания
+	offset := page * count
+
+	found, err := articles.Latest(ctx, uint64(count+1), uint64(offset)) // Лишняя статья показывает что есть следующая страница
+	if err != nil {
+		return "", nil, err
+	}
+
+	var text strings.Builder
+
+	text.WriteString(i18n.T(locale, botkit.LatestHeaderMsg) + "\n")
+
+	if len(found) == 0 {
+		text.WriteString("\n" + i18n.T(locale, botkit.LatestEmptyMsg))
+		return text.String(), nil, nil
+	}
+
+	found, hasNext := pageHasNext(found, count)
+
+	if page > 0 || hasNext {
+		text.WriteString(i18n.T(locale, botkit.PageMsg, page+1) + "\n")
+	}
+
+	names, err := sourceNames(ctx, sources)
+	if err != nil {
+		return "", nil, err
+	}
+
+	for i, article := range found {
+		fmt.Fprintf(&text, "\n%d. %s\n%s · %s\n%s\n",
+			offset+i+1,
+			article.Title,
+			names[article.SourceID],
+			article.Published.Format(dateLayout),
+			article.Link,
+		)
+	}
+
+	keyboard := pageKeyboard(locale, page, hasNext, func(page int) string {
+		return botkit.CallbackData(LatestCallback, strconv.Itoa(count), strconv.Itoa(page))
+	})
+
+	return text.String(), keyboard, nil
+}
+
+func sourceNames(ctx context.Context, sources SourceLister) (map[int64]string, error) { // Функция возвращает имена источников по их ID
+	list, err := sources.Sources(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make(map[int64]string, len(list))
+	for _, source := range list {
+		names[source.ID] = source.Name
+	}
+
+	return names, nil
+}
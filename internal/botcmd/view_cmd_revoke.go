@@ -0,0 +1,62 @@
+package botcmd
+
+import (
+	"context"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/speeddem0n/GoNewsBot/internal/botkit"
+	"github.com/speeddem0n/GoNewsBot/internal/i18n"
+	"github.com/speeddem0n/GoNewsBot/internal/models"
+)
+
+type revokeArgs struct {
+	User int64 `arg:"user" help:"args.user"`
+}
+
+var CmdRevoke = botkit.Command{ // Описание команды revoke
+	Name:        "revoke",
+	Description: botkit.CmdRevokeDescription,
+	Usage:       func(locale i18n.Locale) string { return botkit.ArgsUsage[revokeArgs](locale, "revoke") },
+	Role:        models.RoleAdmin,
+}
+
+func ViewCmdRevoke(roles RoleManager) botkit.ViewFunc { // View для удаления выданной роли пользователя
+	return func(ctx context.Context, bot botkit.Messenger, update tgbotapi.Update) error {
+		var (
+			chatID = update.Message.Chat.ID
+			locale = i18n.FromContext(ctx)
+		)
+
+		args, err := botkit.ParseArgs[revokeArgs](update.Message.CommandArguments())
+		if err != nil {
+			return replyText(bot, chatID, i18n.ErrorText(locale, invalidArgsError[revokeArgs](locale, CmdRevoke.Name, err)))
+		}
+
+		target, ok := targetUserID(update, args.User)
+		if !ok {
+			return replyText(bot, chatID, i18n.T(locale, botkit.GrantUserRequiredMsg))
+		}
+
+		currentRole, err := roles.StoredRole(ctx, target)
+		if err != nil {
+			return err
+		}
+		if currentRole == models.RoleNone {
+			return replyText(bot, chatID, i18n.T(locale, botkit.NoRoleToRevokeMsg, target))
+		}
+
+		allowed, err := canManageRoles(ctx, roles, update.Message.From.ID, target, currentRole)
+		if err != nil {
+			return err
+		}
+		if !allowed {
+			return replyText(bot, chatID, i18n.T(locale, botkit.CantManageRoleMsg))
+		}
+
+		if err := roles.Revoke(ctx, target); err != nil {
+			return err
+		}
+
+		return replyText(bot, chatID, i18n.T(locale, botkit.RoleRevokedMsg, target))
+	}
+}
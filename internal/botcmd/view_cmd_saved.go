@@ -0,0 +1,235 @@
+package botcmd
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/samber/lo"
+	"github.com/speeddem0n/GoNewsBot/internal/botkit"
+	"github.com/speeddem0n/GoNewsBot/internal/i18n"
+	"github.com/speeddem0n/GoNewsBot/internal/models"
+)
+
+type BookmarkStorage interface { // Интерфейс для работы со слоем storage/bookmark.go
+	Add(ctx context.Context, userID, articleID int64) (bool, error)
+	Remove(ctx context.Context, userID, articleID int64) (bool, error)
+	Bookmarks(ctx context.Context, userID int64, limit, offset uint64) ([]models.Article, error)
+}
+
+const (
+	bookmarksPageSize      = 5                   // Сколько закладок показывается на одной странице
+	maxExportedBookmarks   = 1000                // Сколько закладок попадает в выгрузку
+	bookmarksExportName    = "saved_articles.md" // Имя файла с выгрузкой закладок
+	SavedCallback          = "saved"             // Префикс callback_data кнопок листания закладок, данные кнопки содержат номер страницы
+	RemoveBookmarkCallback = "unsave"            // Префикс callback_data кнопок удаления закладки, данные кнопки: номер страницы и ID статьи
+)
+
+var CmdSaved = botkit.Command{ // Описание команды saved
+	Name:        "saved",
+	Description: botkit.CmdSavedDescription,
+	Usage:       func(locale i18n.Locale) string { return i18n.T(locale, botkit.BookmarksUsageMsg) },
+}
+
+var (
+	markdownLinkText = strings.NewReplacer(`\`, `\\`, "[", `\[`, "]", `\]`)    // Экранирование текста ссылки в выгрузке
+	markdownLinkURL  = strings.NewReplacer(" ", "%20", "(", "%28", ")", "%29") // Экранирование адреса ссылки в выгрузке
+)
+
+func ViewCmdSaved(bookmarks BookmarkStorage) botkit.ViewFunc { // View для просмотра закладок: /saved, /saved export
+	return func(ctx context.Context, bot botkit.Messenger, update tgbotapi.Update) error {
+		var (
+			chatID = update.Message.Chat.ID
+			userID = update.Message.From.ID
+			locale = i18n.FromContext(ctx)
+		)
+
+		if !update.Message.Chat.IsPrivate() { // Закладки личные, в группе их увидели бы все участники
+			return replyText(bot, chatID, i18n.T(locale, botkit.BookmarksPrivateOnlyMsg))
+		}
+
+		subcommand, _ := splitSubcommand(update.Message.CommandArguments())
+
+		switch subcommand {
+		case "":
+			text, keyboard, err := savedPage(ctx, bookmarks, locale, userID, 0)
+			if err != nil {
+				return err
+			}
+
+			return sendPage(bot, chatID, text, keyboard, "")
+		case "export":
+			return exportBookmarks(ctx, bot, bookmarks, locale, chatID, userID)
+		default:
+			return replyText(bot, chatID, i18n.T(locale, botkit.BookmarksUsageMsg))
+		}
+	}
+}
+
+func ViewSavedPage(bookmarks BookmarkStorage) botkit.ViewFunc { // View для кнопок листания закладок
+	return func(ctx context.Context, bot botkit.Messenger, update tgbotapi.Update) error {
+		callback := update.CallbackQuery
+
+		numbers, ok := callbackNumbers(update, 1) // Данные кнопки: номер страницы
+		if !ok || callback.Message == nil {
+			return bot.AnswerCallback(tgbotapi.NewCallback(callback.ID, ""))
+		}
+
+		text, keyboard, err := savedPage(ctx, bookmarks, i18n.FromContext(ctx), callback.From.ID, int(numbers[0]))
+		if err != nil {
+			return err
+		}
+
+		return editPage(bot, callback, text, keyboard, "")
+	}
+}
+
+func ViewRemoveBookmark(bookmarks BookmarkStorage) botkit.ViewFunc { // View для кнопок удаления закладки под списком /saved
+	return func(ctx context.Context, bot botkit.Messenger, update tgbotapi.Update) error {
+		var (
+			callback = update.CallbackQuery
+			locale   = i18n.FromContext(ctx)
+		)
+
+		numbers, ok := callbackNumbers(update, 2) // Данные кнопки: номер страницы и ID статьи
+		if !ok || callback.Message == nil {
+			return bot.AnswerCallback(tgbotapi.NewCallback(callback.ID, ""))
+		}
+
+		page := int(numbers[0])
+
+		if _, err := bookmarks.Remove(ctx, callback.From.ID, numbers[1]); err != nil {
+			return err
+		}
+
+		text, keyboard, err := savedPage(ctx, bookmarks, locale, callback.From.ID, page)
+		if err != nil {
+			return err
+		}
+
+		if keyboard == nil && page > 0 { // Удалена последняя закладка на странице, показываем предыдущую
+			if text, keyboard, err = savedPage(ctx, bookmarks, locale, callback.From.ID, page-1); err != nil {
+				return err
+			}
+		}
+
+		edit := tgbotapi.NewEditMessageText(callback.Message.Chat.ID, callback.Message.MessageID, text)
+		edit.DisableWebPagePreview = true
+		edit.ReplyMarkup = keyboard
+
+		if err := bot.Edit(edit); err != nil {
+			return err
+		}
+
+		return bot.AnswerCallback(tgbotapi.NewCallback(callback.ID, i18n.T(locale, botkit.BookmarkRemovedMsg)))
+	}
+}
+
+func ViewSaveArticle(bookmarks BookmarkStorage) botkit.ViewFunc { // View для кнопки "Сохранить" под публикациями, результатами поиска и inline сообщениями
+	return func(ctx context.Context, bot botkit.Messenger, update tgbotapi.Update) error {
+		var (
+			callback = update.CallbackQuery
+			locale   = i18n.FromContext(ctx)
+		)
+
+		numbers, ok := callbackNumbers(update, 1) // Данные кнопки: ID статьи
+		if !ok {
+			return bot.AnswerCallback(tgbotapi.NewCallback(callback.ID, ""))
+		}
+
+		created, err := bookmarks.Add(ctx, callback.From.ID, numbers[0])
+		if err != nil {
+			return err
+		}
+
+		if !created {
+			return bot.AnswerCallback(tgbotapi.NewCallback(callback.ID, i18n.T(locale, botkit.BookmarkAlreadySavedMsg)))
+		}
+
+		return bot.AnswerCallback(tgbotapi.NewCallback(callback.ID, i18n.T(locale, botkit.BookmarkSavedMsg)))
+	}
+}
+
+func savedPage(ctx context.Context, bookmarks BookmarkStorage, locale i18n.Locale, userID int64, page int) (string, *tgbotapi.InlineKeyboardMarkup, error) { // Функция возвращает текст страницы закладок с кнопками удаления и листания
+	offset := page * bookmarksPageSize
+
+	found, err := bookmarks.Bookmarks(ctx, userID, bookmarksPageSize+1, uint64(offset)) // Лишняя статья показывает что есть следующая страница
+	if err != nil {
+		return "", nil, err
+	}
+
+	var text strings.Builder
+
+	text.WriteString(i18n.T(locale, botkit.BookmarksHeaderMsg) + "\n")
+
+	if len(found) == 0 {
+		text.WriteString("\n" + i18n.T(locale, botkit.BookmarksEmptyMsg))
+		return text.String(), nil, nil
+	}
+
+	found, hasNext := pageHasNext(found, bookmarksPageSize)
+
+	if page > 0 || hasNext {
+		text.WriteString(i18n.T(locale, botkit.PageMsg, page+1) + "\n")
+	}
+
+	for i, article := range found {
+		fmt.Fprintf(&text, "\n%d. %s\n%s\n%s\n",
+			offset+i+1,
+			article.Title,
+			article.Published.Format(dateLayout),
+			article.Link,
+		)
+	}
+
+	text.WriteString("\n" + i18n.T(locale, botkit.BookmarksHintMsg))
+
+	removeButtons := lo.Map(found, func(article models.Article, i int) tgbotapi.InlineKeyboardButton {
+		return tgbotapi.NewInlineKeyboardButtonData(
+			fmt.Sprintf("❌ %d", offset+i+1),
+			botkit.CallbackData(RemoveBookmarkCallback, strconv.Itoa(page), strconv.FormatInt(article.ID, 10)),
+		)
+	})
+
+	keyboard := pageKeyboard(locale, page, hasNext, func(page int) string {
+		return botkit.CallbackData(SavedCallback, strconv.Itoa(page))
+	}, removeButtons)
+
+	return text.String(), keyboard, nil
+}
+
+func exportBookmarks(ctx context.Context, bot botkit.Messenger, bookmarks BookmarkStorage, locale i18n.Locale, chatID, userID int64) error { // Функция отправляет закладки пользователя файлом Markdown
+	found, err := bookmarks.Bookmarks(ctx, userID, maxExportedBookmarks, 0)
+	if err != nil {
+		return err
+	}
+
+	if len(found) == 0 {
+		return replyText(bot, chatID, i18n.T(locale, botkit.BookmarksEmptyMsg))
+	}
+
+	var export strings.Builder
+
+	fmt.Fprintf(&export, "# %s\n\n", i18n.T(locale, botkit.BookmarksExportTitle))
+
+	for _, article := range found {
+		fmt.Fprintf(&export, "- [%s](%s) — %s\n",
+			markdownLinkText.Replace(article.Title),
+			markdownLinkURL.Replace(article.Link),
+			article.Published.Format(dateLayout),
+		)
+	}
+
+	doc := tgbotapi.NewDocument(chatID, tgbotapi.FileBytes{
+		Name:  bookmarksExportName,
+		Bytes: []byte(export.String()),
+	})
+
+	if _, err := bot.SendDocument(doc); err != nil {
+		return err
+	}
+
+	return nil
+}
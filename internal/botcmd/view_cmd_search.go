@@ -0,0 +1,200 @@
+package botcmd
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/samber/lo"
+	"github.com/speeddem0n/GoNewsBot/internal/botkit"
+	"github.com/speeddem0n/GoNewsBot/internal/i18n"
+	"github.com/speeddem0n/GoNewsBot/internal/models"
+)
+
+type ArticleSearcher interface { // Интерфейс для работы со слоем storage/article.go
+	Search(ctx context.Context, query models.ArticleQuery) ([]models.Article, error)
+}
+
+const (
+	searchPageSize = 5        // Сколько статей показывается на одной странице
+	searchHeader   = "🔎 "     // Первая строка ответа содержит запрос, по ней кнопки листают результаты без хранения состояния
+	SearchCallback = "search" // Префикс callback_data кнопок листания, данные кнопки содержат номер страницы
+)
+
+var CmdSearch = botkit.Command{ // Описание команды search
+	Name:        "search",
+	Description: botkit.CmdSearchDescription,
+	Usage:       func(locale i18n.Locale) string { return i18n.T(locale, botkit.SearchUsageMsg) },
+}
+
+func ViewCmdSearch(articles ArticleSearcher) botkit.ViewFunc { // View для полнотекстового поиска по статьям
+	return func(ctx context.Context, bot botkit.Messenger, update tgbotapi.Update) error {
+		var (
+			chatID = update.Message.Chat.ID
+			locale = i18n.FromContext(ctx)
+			raw    = strings.Join(strings.Fields(update.Message.CommandArguments()), " ") // Запрос хранится в одной строке заголовка
+		)
+
+		if raw == "" {
+			return replyText(bot, chatID, i18n.T(locale, botkit.SearchUsageMsg))
+		}
+
+		text, keyboard, err := searchPage(ctx, articles, locale, raw, 0)
+		if err != nil {
+			return replyUserError(bot, chatID, locale, err)
+		}
+
+		return sendPage(bot, chatID, text, keyboard, "")
+	}
+}
+
+func ViewSearchPage(articles ArticleSearcher) botkit.ViewFunc { // View для кнопок листания результатов поиска
+	return func(ctx context.Context, bot botkit.Messenger, update tgbotapi.Update) error {
+		var (
+			callback = update.CallbackQuery
+			locale   = i18n.FromContext(ctx)
+		)
+
+		numbers, ok := callbackNumbers(update, 1) // Данные кнопки: номер страницы
+		if !ok || callback.Message == nil {
+			return bot.AnswerCallback(tgbotapi.NewCallback(callback.ID, ""))
+		}
+
+		header, _, _ := strings.Cut(callback.Message.Text, "\n")
+		raw, ok := strings.CutPrefix(header, searchHeader)
+		if !ok || raw == "" {
+			return bot.AnswerCallback(tgbotapi.NewCallback(callback.ID, i18n.T(locale, botkit.SearchExpiredMsg)))
+		}
+
+		text, keyboard, err := searchPage(ctx, articles, locale, raw, int(numbers[0]))
+		if err != nil {
+			return answerPageError(bot, callback, locale, err)
+		}
+
+		return editPage(bot, callback, text, keyboard, "")
+	}
+}
+
+func searchPage(ctx context.Context, articles ArticleSearcher, locale i18n.Locale, raw string, page int) (string, *tgbotapi.InlineKeyboardMarkup, error) { // Функция ищет статьи и возвращает текст страницы с кнопками листания
+	query, err := parseSearchQuery(raw, time.Now())
+	if err != nil {
+		return "", nil, err
+	}
+
+	query.Limit = searchPageSize + 1 // Лишняя статья показывает что есть следующая страница
+	query.Offset = uint64(page * searchPageSize)
+
+	found, err := articles.Search(ctx, query)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var text strings.Builder
+
+	text.WriteString(searchHeader + raw + "\n")
+
+	if len(found) == 0 {
+		text.WriteString("\n" + i18n.T(locale, botkit.SearchNoResultsMsg))
+		return text.String(), nil, nil
+	}
+
+	found, hasNext := pageHasNext(found, searchPageSize)
+
+	if page > 0 || hasNext {
+		text.WriteString(i18n.T(locale, botkit.PageMsg, page+1) + "\n")
+	}
+
+	for i, article := range found {
+		fmt.Fprintf(&text, "\n%d. %s\n%s\n%s\n",
+			int(query.Offset)+i+1,
+			article.Title,
+			article.Published.Format(dateLayout),
+			article.Link,
+		)
+	}
+
+	saveButtons := lo.Map(found, func(article models.Article, i int) tgbotapi.InlineKeyboardButton { // Кнопки "🔖 N" сохраняют N-ю статью в закладки
+		return botkit.SaveArticleButton(fmt.Sprintf("🔖 %d", int(query.Offset)+i+1), article.ID)
+	})
+
+	keyboard := pageKeyboard(locale, page, hasNext, func(page int) string {
+		return botkit.CallbackData(SearchCallback, strconv.Itoa(page))
+	}, saveButtons)
+
+	return text.String(), keyboard, nil
+}
+
+func parseSearchQuery(raw string, now time.Time) (models.ArticleQuery, error) { // Функция отделяет фильтры source: и since: от текста запроса
+	var (
+		query models.ArticleQuery
+		words []string
+	)
+
+	tokens, err := botkit.SplitArgs(raw) // Название источника с пробелами берется в кавычки: source:"Hacker News"
+	if err != nil {
+		tokens = strings.Fields(raw) // Незакрытая кавычка не ошибка, запрос ищется по словам как есть
+	}
+
+	for _, token := range tokens {
+		key, value, ok := strings.Cut(token, ":")
+
+		switch {
+		case ok && value != "" && strings.EqualFold(key, "source"):
+			if id, err := strconv.ParseInt(value, 10, 64); err == nil {
+				query.SourceID = id
+			} else {
+				query.SourceName = value
+			}
+		case ok && value != "" && strings.EqualFold(key, "since"):
+			since, err := parseSince(value, now)
+			if err != nil {
+				return models.ArticleQuery{}, i18n.NewError(botkit.SearchInvalidSinceMsg, value)
+			}
+			query.Since = since
+		case strings.ContainsAny(token, " \t\n"):
+			words = append(words, `"`+token+`"`) // Кавычки сохраняются, фраза ищется целиком
+		default:
+			words = append(words, token)
+		}
+	}
+
+	query.Text = strings.Join(words, " ")
+
+	return query, nil
+}
+
+func parseSince(value string, now time.Time) (time.Time, error) { // Функция разбирает дату вида 2025-01-31 или период вида 24h, 7d, 2w
+	if date, err := time.Parse(time.DateOnly, value); err == nil {
+		return date, nil
+	}
+
+	value = strings.ToLower(value)
+
+	n, err := strconv.Atoi(value[:len(value)-1])
+	if err != nil || n <= 0 {
+		return time.Time{}, fmt.Errorf("invalid period %q", value)
+	}
+
+	var unit time.Duration
+
+	switch value[len(value)-1] {
+	case 'h':
+		unit = time.Hour
+	case 'd':
+		unit = 24 * time.Hour
+	case 'w':
+		unit = 7 * 24 * time.Hour
+	default:
+		return time.Time{}, fmt.Errorf("invalid period %q", value)
+	}
+
+	if n > int(math.MaxInt64/unit) { // Иначе time.Duration переполнится и дата окажется в будущем
+		return time.Time{}, fmt.Errorf("period %q is too long", value)
+	}
+
+	return now.Add(-time.Duration(n) * unit), nil
+}
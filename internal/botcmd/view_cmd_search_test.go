@@ -0,0 +1,77 @@
+package botcmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/speeddem0n/GoNewsBot/internal/models"
+)
+
+var searchNow = time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+
+func TestParseSearchQuery(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		want    models.ArticleQuery
+		wantErr bool
+	}{
+		{name: "text only", raw: "generic types", want: models.ArticleQuery{Text: "generic types"}},
+		{name: "source id", raw: "source:42 go", want: models.ArticleQuery{Text: "go", SourceID: 42}},
+		{name: "source name", raw: "SOURCE:habr go", want: models.ArticleQuery{Text: "go", SourceName: "habr"}},
+		{name: "quoted source name", raw: `source:"Hacker News" rust`, want: models.ArticleQuery{Text: "rust", SourceName: "Hacker News"}},
+		{name: "source name in guillemets", raw: "go source:«Go Blog»", want: models.ArticleQuery{Text: "go", SourceName: "Go Blog"}},
+		{name: "quoted phrase is kept", raw: `"generic types" go`, want: models.ArticleQuery{Text: `"generic types" go`}},
+		{name: "unterminated quote", raw: `"generic types`, want: models.ArticleQuery{Text: `"generic types`}},
+		{name: "since date", raw: "since:2025-01-31 go", want: models.ArticleQuery{Text: "go", Since: time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)}},
+		{name: "since period", raw: "go since:7d", want: models.ArticleQuery{Text: "go", Since: searchNow.Add(-7 * 24 * time.Hour)}},
+		{name: "empty filter is text", raw: "source: go", want: models.ArticleQuery{Text: "source: go"}},
+		{name: "invalid since", raw: "go since:7m", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseSearchQuery(tt.raw, searchNow)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseSearchQuery(%q) error = %v, wantErr %v", tt.raw, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("parseSearchQuery(%q) = %+v, want %+v", tt.raw, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseSince(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    time.Time
+		wantErr bool
+	}{
+		{value: "2025-01-31", want: time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)},
+		{value: "24h", want: searchNow.Add(-24 * time.Hour)},
+		{value: "7d", want: searchNow.Add(-7 * 24 * time.Hour)},
+		{value: "2W", want: searchNow.Add(-14 * 24 * time.Hour)},
+		{value: "15250w", want: searchNow.Add(-15250 * 7 * 24 * time.Hour)},
+		{value: "99999999999w", wantErr: true}, // Период не помещается в time.Duration
+		{value: "99999999999999999999d", wantErr: true},
+		{value: "0d", wantErr: true},
+		{value: "-1d", wantErr: true},
+		{value: "7m", wantErr: true},
+		{value: "d", wantErr: true},
+		{value: "2025-13-01", wantErr: true},
+		{value: "yesterday", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := parseSince(tt.value, searchNow)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseSince(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("parseSince(%q) = %s, want %s", tt.value, got, tt.want)
+			}
+		})
+	}
+}
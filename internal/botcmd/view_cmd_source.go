@@ -0,0 +1,165 @@
+package botcmd
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/speeddem0n/GoNewsBot/internal/botkit"
+	"github.com/speeddem0n/GoNewsBot/internal/botkit/markup"
+	"github.com/speeddem0n/GoNewsBot/internal/i18n"
+	"github.com/speeddem0n/GoNewsBot/internal/models"
+)
+
+type SourceArticles interface { // Интерфейс для работы со слоем storage/article.go
+	BySource(ctx context.Context, sourceID int64, limit, offset uint64) ([]models.Article, error)
+	SourceStats(ctx context.Context, sourceID int64) (models.SourceStats, error)
+}
+
+const (
+	sourceArticlesPageSize = 10       // Сколько статей источника показывается на одной странице
+	maxSourceErrorLength   = 200      // Длинные ошибки загрузки обрезаются, чтобы не растягивать сообщение
+	SourceCallback         = "source" // Префикс callback_data кнопок листания, данные кнопки: ID источника и номер страницы
+)
+
+type sourceInfoArgs struct {
+	ID int64 `arg:"id,required" help:"args.source_id"`
+}
+
+var CmdSource = botkit.Command{ // Описание команды source
+	Name:        "source",
+	Description: botkit.CmdSourceDescription,
+	Usage:       func(locale i18n.Locale) string { return botkit.ArgsUsage[sourceInfoArgs](locale, "source") },
+	Role:        models.RoleViewer,
+}
+
+func ViewCmdSource(sources SourceFinder, articles SourceArticles) botkit.ViewFunc { // View с информацией об источнике, состоянием его загрузки и последними статьями
+	return func(ctx context.Context, bot botkit.Messenger, update tgbotapi.Update) error {
+		var (
+			chatID = update.Message.Chat.ID
+			locale = i18n.FromContext(ctx)
+		)
+
+		args, err := botkit.ParseArgs[sourceInfoArgs](update.Message.CommandArguments())
+		if err != nil {
+			return replyUserError(bot, chatID, locale, invalidArgsError[sourceInfoArgs](locale, CmdSource.Name, err))
+		}
+
+		text, keyboard, err := sourcePage(ctx, sources, articles, locale, args.ID, 0)
+		if err != nil {
+			return replyUserError(bot, chatID, locale, err)
+		}
+
+		return sendPage(bot, chatID, text, keyboard, tgbotapi.ModeMarkdownV2)
+	}
+}
+
+func ViewSourcePage(sources SourceFinder, articles SourceArticles) botkit.ViewFunc { // View для кнопок листания статей источника
+	return func(ctx context.Context, bot botkit.Messenger, update tgbotapi.Update) error {
+		var (
+			callback = update.CallbackQuery
+			locale   = i18n.FromContext(ctx)
+		)
+
+		numbers, ok := callbackNumbers(update, 2) // Данные кнопки: ID источника и номер страницы
+		if !ok || callback.Message == nil {
+			return bot.AnswerCallback(tgbotapi.NewCallback(callback.ID, ""))
+		}
+
+		text, keyboard, err := sourcePage(ctx, sources, articles, locale, numbers[0], int(numbers[1]))
+		if err != nil {
+			return answerPageError(bot, callback, locale, err)
+		}
+
+		return editPage(bot, callback, text, keyboard, tgbotapi.ModeMarkdownV2)
+	}
+}
+
+func sourcePage(ctx context.Context, sources SourceFinder, articles SourceArticles, locale i18n.Locale, id int64, page int) (string, *tgbotapi.InlineKeyboardMarkup, error) { // Функция возвращает текст страницы источника в MarkdownV2 с кнопками листания
+	if id <= 0 {
+		return "", nil, i18n.NewError(botkit.InvalidSourceIDMsg)
+	}
+
+	source, err := sources.SourceByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", nil, i18n.NewError(botkit.SourceNotFoundMsg)
+		}
+		return "", nil, err
+	}
+
+	stats, err := articles.SourceStats(ctx, id)
+	if err != nil {
+		return "", nil, err
+	}
+
+	offset := page * sourceArticlesPageSize
+
+	found, err := articles.BySource(ctx, id, sourceArticlesPageSize+1, uint64(offset)) // Лишняя статья показывает что есть следующая страница
+	if err != nil {
+		return "", nil, err
+	}
+
+	found, hasNext := pageHasNext(found, sourceArticlesPageSize)
+
+	var text strings.Builder
+
+	text.WriteString(formatSource(locale, *source) + "\n\n")
+	text.WriteString(formatSourceHealth(locale, *source) + "\n")
+
+	if stats.Articles == 0 {
+		text.WriteString("\n" + i18n.T(locale, botkit.SourceNoArticlesMsg))
+		return text.String(), nil, nil
+	}
+
+	text.WriteString(i18n.T(locale, botkit.SourceStatsMsg,
+		stats.Articles,
+		stats.Posted,
+		markup.EscapeForMarkdown(stats.LastPublished.Format(dateLayout)),
+	) + "\n\n")
+
+	if page > 0 || hasNext {
+		text.WriteString(markup.EscapeForMarkdown(i18n.T(locale, botkit.PageMsg, page+1)) + "\n")
+	}
+	text.WriteString(i18n.T(locale, botkit.SourceArticlesMsg) + "\n")
+
+	for i, article := range found {
+		status := "🕓"
+		if !article.Posted.IsZero() {
+			status = "✅"
+		}
+
+		fmt.Fprintf(&text, "\n%d\\. %s [%s](%s) · %s",
+			offset+i+1,
+			status,
+			markup.EscapeForMarkdown(article.Title),
+			markup.EscapeLinkURL(article.Link),
+			markup.EscapeForMarkdown(article.Published.Format(dateLayout)),
+		)
+	}
+
+	keyboard := pageKeyboard(locale, page, hasNext, func(page int) string {
+		return botkit.CallbackData(SourceCallback, strconv.FormatInt(id, 10), strconv.Itoa(page))
+	})
+
+	return text.String(), keyboard, nil
+}
+
+func formatSourceHealth(locale i18n.Locale, source models.Source) string { // Функция описывает состояние загрузки источника в MarkdownV2
+	switch {
+	case source.LastFetched.IsZero():
+		return i18n.T(locale, botkit.SourceHealthUnknownMsg)
+	case source.ErrorCount > 0:
+		return i18n.T(locale, botkit.SourceHealthFailingMsg,
+			source.ErrorCount,
+			markup.EscapeForMarkdown(source.LastFetched.Format(dateTimeLayout)),
+			markup.EscapeForMarkdown(truncateText(source.LastError, maxSourceErrorLength)),
+		)
+	default:
+		return i18n.T(locale, botkit.SourceHealthOKMsg, markup.EscapeForMarkdown(source.LastFetched.Format(dateTimeLayout)))
+	}
+}
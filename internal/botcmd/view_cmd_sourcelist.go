@@ -2,13 +2,13 @@ package botcmd
 
 import (
 	"context"
-	"fmt"
 	"strings"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 	"github.com/samber/lo"
 	"github.com/speeddem0n/GoNewsBot/internal/botkit"
 	"github.com/speeddem0n/GoNewsBot/internal/botkit/markup"
+	"github.com/speeddem0n/GoNewsBot/internal/i18n"
 	"github.com/speeddem0n/GoNewsBot/internal/models"
 )
 
@@ -16,18 +16,25 @@ type SourceLister interface { // Интерфейс для работы со с
 	Sources(ctx context.Context) ([]models.Source, error)
 }
 
+var CmdListSources = botkit.Command{ // Описание команды list
+	Name:        "list",
+	Description: botkit.CmdListDescription,
+	Role:        models.RoleViewer,
+}
+
 func ViewCmdListSources(lister SourceLister) botkit.ViewFunc { // View для вывода списка всех источников
-	return func(ctx context.Context, bot *tgbotapi.BotAPI, update tgbotapi.Update) error {
+	return func(ctx context.Context, bot botkit.Messenger, update tgbotapi.Update) error {
 		sources, err := lister.Sources(ctx)
 		if err != nil {
 			return err
 		}
 
 		var (
+			locale      = i18n.FromContext(ctx)
 			sourcesInfo = lo.Map(sources, func(source models.Source, _ int) string {
-				return formatSource(source)
+				return formatSource(locale, source)
 			}) // Форматируем список источников в читаймый вид
-			msgText = fmt.Sprintf("Список источников\\(Всего %d\\):\n\n%s", len(sources), strings.Join(sourcesInfo, "\n\n")) // Финальное сообзение для пользователя
+			msgText = i18n.T(locale, botkit.SourceListMsg, len(sources), strings.Join(sourcesInfo, "\n\n")) // Финальное сообзение для пользователя
 		)
 
 		reply := tgbotapi.NewMessage(update.Message.Chat.ID, msgText) // Формеруем ответ пользователю
@@ -41,8 +48,8 @@ func ViewCmdListSources(lister SourceLister) botkit.ViewFunc { // View для в
 	}
 }
 
-func formatSource(source models.Source) string { // Функция для форматирования инфо об источнике
-	return fmt.Sprintf("🌎 *%s*\nID: `%d`\nURL feed: %s",
+func formatSource(locale i18n.Locale, source models.Source) string { // Функция для форматирования инфо об источнике
+	return i18n.T(locale, botkit.SourceInfoMsg,
 		markup.EscapeForMarkdown(source.Name), // Функция для замены спецсимволов markdown в тексте
 		source.ID,
 		markup.EscapeForMarkdown(source.FeedURL), // Функция для замены спецсимволов markdown в тексте
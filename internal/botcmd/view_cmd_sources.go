@@ -0,0 +1,62 @@
+package botcmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/samber/lo"
+	"github.com/speeddem0n/GoNewsBot/internal/botkit"
+	"github.com/speeddem0n/GoNewsBot/internal/i18n"
+	"github.com/speeddem0n/GoNewsBot/internal/models"
+)
+
+type SubscriptionStorage interface { // Интерфейс для работы со слоем storage/subscription.go
+	Subscribe(ctx context.Context, userID, sourceID int64) (bool, error)
+	Unsubscribe(ctx context.Context, userID, sourceID int64) (bool, error)
+	SubscribedSourceIDs(ctx context.Context, userID int64) ([]int64, error)
+}
+
+var CmdSources = botkit.Command{ // Описание команды sources, доступна любому пользователю
+	Name:        "sources",
+	Description: botkit.CmdSourcesDescription,
+}
+
+func ViewCmdSources(lister SourceLister, subscriptions SubscriptionStorage) botkit.ViewFunc { // View со списком источников и подписками пользователя
+	return func(ctx context.Context, bot botkit.Messenger, update tgbotapi.Update) error {
+		var (
+			chatID = update.Message.Chat.ID
+			locale = i18n.FromContext(ctx)
+		)
+
+		if !update.Message.Chat.IsPrivate() {
+			return replyText(bot, chatID, i18n.T(locale, botkit.SubscriptionPrivateOnlyMsg))
+		}
+
+		sources, err := lister.Sources(ctx)
+		if err != nil {
+			return err
+		}
+
+		if len(sources) == 0 {
+			return replyText(bot, chatID, i18n.T(locale, botkit.SourcesEmptyMsg))
+		}
+
+		subscribed, err := subscriptions.SubscribedSourceIDs(ctx, update.Message.From.ID)
+		if err != nil {
+			return err
+		}
+
+		lines := lo.Map(sources, func(source models.Source, _ int) string { // Отмечаем источники на которые пользователь уже подписан
+			mark := "▫️"
+			if lo.Contains(subscribed, source.ID) {
+				mark = "✅"
+			}
+
+			return fmt.Sprintf("%s %d. %s", mark, source.ID, source.Name)
+		})
+
+		return replyText(bot, chatID, i18n.T(locale, botkit.SourcesBrowseMsg, strings.Join(lines, "\n")))
+	}
+}
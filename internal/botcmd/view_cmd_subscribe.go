@@ -0,0 +1,87 @@
+package botcmd
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/speeddem0n/GoNewsBot/internal/botkit"
+	"github.com/speeddem0n/GoNewsBot/internal/i18n"
+	"github.com/speeddem0n/GoNewsBot/internal/models"
+)
+
+type SourceFinder interface { // Интерфейс для работы со слоем storage
+	SourceByID(ctx context.Context, id int64) (*models.Source, error)
+}
+
+type subscribeArgs struct {
+	ID int64 `arg:"id,required" help:"args.source_id"`
+}
+
+var CmdSubscribe = botkit.Command{ // Описание команды subscribe
+	Name:        "subscribe",
+	Description: botkit.CmdSubscribeDescription,
+	Usage:       func(locale i18n.Locale) string { return botkit.ArgsUsage[subscribeArgs](locale, "subscribe") },
+}
+
+func ViewCmdSubscribe(sources SourceFinder, subscriptions SubscriptionStorage) botkit.ViewFunc { // View для подписки пользователя на источник
+	return func(ctx context.Context, bot botkit.Messenger, update tgbotapi.Update) error {
+		var (
+			chatID = update.Message.Chat.ID
+			locale = i18n.FromContext(ctx)
+		)
+
+		source, err := subscriptionSource(ctx, sources, update, CmdSubscribe.Name)
+		if err != nil {
+			return replyUserError(bot, chatID, locale, err)
+		}
+
+		created, err := subscriptions.Subscribe(ctx, update.Message.From.ID, source.ID)
+		if err != nil {
+			return err
+		}
+
+		if !created {
+			return replyText(bot, chatID, i18n.T(locale, botkit.AlreadySubscribedMsg, source.Name))
+		}
+
+		return replyText(bot, chatID, i18n.T(locale, botkit.SubscribedMsg, source.Name))
+	}
+}
+
+func subscriptionSource(ctx context.Context, sources SourceFinder, update tgbotapi.Update, cmd string) (*models.Source, error) { // Функция проверяет чат и аргументы команды и возвращает источник для подписки
+	locale := i18n.FromContext(ctx)
+
+	if !update.Message.Chat.IsPrivate() { // Статьи отправляются в личные сообщения, поэтому подписки в группах не имеют смысла
+		return nil, i18n.NewError(botkit.SubscriptionPrivateOnlyMsg)
+	}
+
+	args, err := botkit.ParseArgs[subscribeArgs](update.Message.CommandArguments())
+	if err != nil {
+		return nil, invalidArgsError[subscribeArgs](locale, cmd, err)
+	}
+
+	if args.ID <= 0 {
+		return nil, i18n.NewError(botkit.InvalidSourceIDMsg)
+	}
+
+	source, err := sources.SourceByID(ctx, args.ID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, i18n.NewError(botkit.SourceNotFoundMsg)
+		}
+		return nil, err
+	}
+
+	return source, nil
+}
+
+func replyUserError(bot botkit.Messenger, chatID int64, locale i18n.Locale, err error) error { // Функция отправляет пользователю ошибку i18n.Error, остальные ошибки возвращаются как есть
+	var userErr *i18n.Error
+	if !errors.As(err, &userErr) {
+		return err
+	}
+
+	return replyText(bot, chatID, i18n.ErrorText(locale, err))
+}
@@ -0,0 +1,40 @@
+package botcmd
+
+import (
+	"context"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/speeddem0n/GoNewsBot/internal/botkit"
+	"github.com/speeddem0n/GoNewsBot/internal/i18n"
+)
+
+var CmdUnsubscribe = botkit.Command{ // Описание команды unsubscribe
+	Name:        "unsubscribe",
+	Description: botkit.CmdUnsubscribeDescription,
+	Usage:       func(locale i18n.Locale) string { return botkit.ArgsUsage[subscribeArgs](locale, "unsubscribe") },
+}
+
+func ViewCmdUnsubscribe(sources SourceFinder, subscriptions SubscriptionStorage) botkit.ViewFunc { // View для отписки пользователя от источника
+	return func(ctx context.Context, bot botkit.Messenger, update tgbotapi.Update) error {
+		var (
+			chatID = update.Message.Chat.ID
+			locale = i18n.FromContext(ctx)
+		)
+
+		source, err := subscriptionSource(ctx, sources, update, CmdUnsubscribe.Name)
+		if err != nil {
+			return replyUserError(bot, chatID, locale, err)
+		}
+
+		deleted, err := subscriptions.Unsubscribe(ctx, update.Message.From.ID, source.ID)
+		if err != nil {
+			return err
+		}
+
+		if !deleted {
+			return replyText(bot, chatID, i18n.T(locale, botkit.NotSubscribedMsg, source.Name))
+		}
+
+		return replyText(bot, chatID, i18n.T(locale, botkit.UnsubscribedMsg, source.Name))
+	}
+}
@@ -0,0 +1,134 @@
+package botcmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/speeddem0n/GoNewsBot/internal/botkit"
+	"github.com/speeddem0n/GoNewsBot/internal/i18n"
+	"github.com/speeddem0n/GoNewsBot/internal/models"
+	"github.com/speeddem0n/GoNewsBot/internal/summary"
+)
+
+const (
+	defaultUsageDays = 7  // Сколько последних дней показывает /usage без аргументов
+	maxUsageDays     = 31 // Больше дней не помещается в одно сообщение
+	maxUsageLines    = 10 // Сколько самых дорогих источников и каналов показывается
+)
+
+type UsageReporter interface { // Интерфейс для работы со слоем storage/usage.go
+	UsageByDay(ctx context.Context, since time.Time) ([]models.UsageTotal, error)
+	UsageBySource(ctx context.Context, since time.Time) ([]models.UsageTotal, error)
+	UsageByChat(ctx context.Context, since time.Time) ([]models.UsageTotal, error)
+}
+
+type usageArgs struct {
+	Days int `arg:"days" help:"args.usage_days"`
+}
+
+var CmdUsage = botkit.Command{ // Описание команды usage
+	Name:        "usage",
+	Description: botkit.CmdUsageDescription,
+	Usage:       func(locale i18n.Locale) string { return botkit.ArgsUsage[usageArgs](locale, "usage") },
+	Role:        models.RoleAdmin,
+}
+
+func ViewCmdUsage(usage UsageReporter, budget float64) botkit.ViewFunc { // View для просмотра расхода токенов на саммари, budget - месячный бюджет в долларах
+	return func(ctx context.Context, bot botkit.Messenger, update tgbotapi.Update) error {
+		var (
+			chatID = update.Message.Chat.ID
+			locale = i18n.FromContext(ctx)
+		)
+
+		args, err := botkit.ParseArgs[usageArgs](update.Message.CommandArguments())
+		if err != nil || args.Days < 0 {
+			return replyText(bot, chatID, i18n.ErrorText(locale, invalidArgsError[usageArgs](locale, CmdUsage.Name, err)))
+		}
+
+		days := args.Days
+		if days == 0 {
+			days = defaultUsageDays
+		}
+		days = min(days, maxUsageDays)
+
+		now := time.Now().UTC()
+		monthStart := summary.MonthStart(now)
+		today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+		bySource, err := usage.UsageBySource(ctx, monthStart)
+		if err != nil {
+			return err
+		}
+
+		byChat, err := usage.UsageByChat(ctx, monthStart)
+		if err != nil {
+			return err
+		}
+
+		byDay, err := usage.UsageByDay(ctx, today.AddDate(0, 0, 1-days))
+		if err != nil {
+			return err
+		}
+
+		month := models.UsageTotal{}
+		for _, total := range bySource { // Каждый запрос относится ровно к одному источнику, поэтому сумма по источникам - расход за месяц
+			month.Requests += total.Requests
+			month.PromptTokens += total.PromptTokens
+			month.CompletionTokens += total.CompletionTokens
+			month.Cost += total.Cost
+		}
+
+		var text strings.Builder
+		text.WriteString(i18n.T(locale, botkit.UsageHeaderMsg) + "\n\n")
+
+		if budget > 0 {
+			text.WriteString(i18n.T(locale, botkit.UsageBudgetMsg, month.Cost, budget, month.Cost/budget*100) + "\n")
+			if month.Cost >= budget {
+				text.WriteString(i18n.T(locale, botkit.UsageBudgetExceededMsg) + "\n")
+			}
+		} else {
+			text.WriteString(i18n.T(locale, botkit.UsageNoBudgetMsg, month.Cost) + "\n")
+		}
+
+		if month.Requests == 0 {
+			text.WriteString("\n" + i18n.T(locale, botkit.UsageEmptyMsg))
+			return replyText(bot, chatID, text.String())
+		}
+
+		text.WriteString(i18n.T(locale, botkit.UsageMonthMsg, usageTotalText(locale, month)) + "\n")
+
+		for _, section := range []struct {
+			title  string
+			totals []models.UsageTotal
+			limit  int
+		}{
+			{i18n.T(locale, botkit.UsageDaysMsg, days), byDay, days},
+			{i18n.T(locale, botkit.UsageSourcesMsg), bySource, maxUsageLines},
+			{i18n.T(locale, botkit.UsageChatsMsg), byChat, maxUsageLines},
+		} {
+			if len(section.totals) == 0 {
+				continue
+			}
+
+			text.WriteString("\n" + section.title + "\n")
+			for _, total := range section.totals[:min(len(section.totals), section.limit)] {
+				text.WriteString(fmt.Sprintf("%s — %s\n", total.Key, usageTotalText(locale, total)))
+			}
+		}
+
+		return replyText(bot, chatID, strings.TrimSpace(text.String()))
+	}
+}
+
+func usageTotalText(locale i18n.Locale, total models.UsageTotal) string { // Функция форматирует суммарный расход для сообщения
+	return i18n.T(locale, botkit.UsageTotalMsg,
+		total.Requests,
+		total.PromptTokens+total.CompletionTokens,
+		total.PromptTokens,
+		total.CompletionTokens,
+		total.Cost,
+	)
+}
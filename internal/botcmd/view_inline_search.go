@@ -0,0 +1,106 @@
+package botcmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+	"unicode/utf8"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/samber/lo"
+	"github.com/speeddem0n/GoNewsBot/internal/botkit"
+	"github.com/speeddem0n/GoNewsBot/internal/botkit/markup"
+	"github.com/speeddem0n/GoNewsBot/internal/i18n"
+	"github.com/speeddem0n/GoNewsBot/internal/models"
+)
+
+const (
+	inlinePageSize          = 20  // Сколько статей возвращается за один inline запрос, телеграм принимает до 50
+	inlineCacheTime         = 60  // Сколько секунд телеграм кэширует ответ на одинаковый запрос
+	inlineDescriptionLength = 200 // Длина описания статьи в списке результатов
+	inlineSummaryLength     = 600 // Длина описания статьи в отправляемом сообщении
+)
+
+func ViewInlineSearch(articles ArticleSearcher) botkit.ViewFunc { // View отвечает на inline запросы статьями из поиска, пустой запрос возвращает последние статьи
+	return func(ctx context.Context, bot botkit.Messenger, update tgbotapi.Update) error {
+		var (
+			inline = update.InlineQuery
+			locale = i18n.FromContext(ctx)
+			answer = tgbotapi.InlineConfig{
+				InlineQueryID: inline.ID,
+				CacheTime:     inlineCacheTime,
+				IsPersonal:    true, // Подпись под статьей зависит от языка пользователя
+				Results:       []any{},
+			}
+		)
+
+		query, err := parseSearchQuery(inline.Query, time.Now())
+		if err != nil {
+			var userErr *i18n.Error
+			if !errors.As(err, &userErr) {
+				return err
+			}
+
+			return bot.AnswerInline(answer) // Пока пользователь набирает фильтр, например since:7, запрос может быть некорректным
+		}
+
+		offset, _ := strconv.ParseUint(inline.Offset, 10, 64) // Телеграм присылает offset из NextOffset предыдущего ответа при прокрутке результатов
+
+		query.Limit = inlinePageSize + 1 // Лишняя статья показывает что есть следующая страница
+		query.Offset = offset
+
+		found, err := articles.Search(ctx, query)
+		if err != nil {
+			return err
+		}
+
+		found, hasNext := pageHasNext(found, inlinePageSize)
+		if hasNext {
+			answer.NextOffset = strconv.FormatUint(offset+inlinePageSize, 10)
+		}
+
+		answer.Results = lo.Map(found, func(article models.Article, _ int) any {
+			result := tgbotapi.NewInlineQueryResultArticleMarkdownV2(
+				strconv.FormatInt(article.ID, 10),
+				article.Title,
+				inlineArticleText(locale, article),
+			)
+			result.Description = truncateText(markup.PlainText(article.Summary), inlineDescriptionLength)
+			result.URL = article.Link
+
+			keyboard := botkit.SaveArticleKeyboard(locale, article.ID)
+			result.ReplyMarkup = &keyboard
+
+			return result
+		})
+
+		return bot.AnswerInline(answer)
+	}
+}
+
+func inlineArticleText(locale i18n.Locale, article models.Article) string { // Функция формирует сообщение которое пользователь отправит в чат, в том же виде что и публикации в канале
+	const msgFormat = "*%s*%s\n\n[%s](%s)" // Шаблон сообщения
+
+	summary := truncateText(markup.PlainText(article.Summary), inlineSummaryLength)
+	if summary != "" {
+		summary = "\n\n" + markup.EscapeForMarkdown(summary)
+	}
+
+	return fmt.Sprintf(
+		msgFormat,
+		markup.EscapeForMarkdown(article.Title),
+		summary,
+		markup.EscapeForMarkdown(i18n.T(locale, botkit.ReadMoreMsg)),
+		markup.EscapeLinkURL(article.Link),
+	)
+}
+
+func truncateText(text string, length int) string { // Функция обрезает текст до length символов
+	if utf8.RuneCountInString(text) <= length {
+		return text
+	}
+
+	return string([]rune(text)[:length-1]) + "…"
+}
@@ -1,6 +1,16 @@
 package botkit
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/speeddem0n/GoNewsBot/internal/i18n"
+)
 
 func ParseJSON[T any](src string) (T, error) { // Функция для парсинга json объектов
 	var args T
@@ -11,3 +21,388 @@ func ParseJSON[T any](src string) (T, error) { // Функция для парс
 
 	return args, nil
 }
+
+/*
+ParseArgs разбирает аргументы команды в структуру T. Поля описываются тегами:
+
+	Name string `arg:"name,required" help:"args.source_name"`
+
+Аргументы можно передавать по порядку полей (/add Golang https://go.dev/blog/feed.atom),
+в виде key=value (/edit 5 name="Go Blog") или JSON объектом (/delete {"id":5}).
+Значения с пробелами берутся в кавычки, одинарная кавычка открывает значение только в начале аргумента (O'Reilly).
+Опция rest у последнего поля забирает все оставшиеся позиционные аргументы.
+Опция url забирает позиционный аргумент похожий на ссылку независимо от его места (/add https://go.dev/blog/feed.atom).
+*/
+func ParseArgs[T any](src string) (T, error) {
+	var args T
+
+	v := reflect.ValueOf(&args).Elem()
+	if v.Kind() != reflect.Struct {
+		return *(new(T)), fmt.Errorf("args type %s is not a struct", v.Type())
+	}
+
+	fields := argFields(v.Type())
+	set := make(map[string]bool) // Поля которым было присвоено значение
+
+	src = strings.TrimSpace(src)
+
+	var err error
+	if strings.HasPrefix(src, "{") { // Аргументы переданы JSON объектом
+		err = parseJSONArgs(src, v, fields, set)
+	} else {
+		err = parseTextArgs(src, v, fields, set)
+	}
+	if err != nil {
+		return *(new(T)), err
+	}
+
+	for _, field := range fields { // Проверяем что все обязательные аргументы переданы
+		if field.required && !set[field.name] {
+			return *(new(T)), fmt.Errorf("missing required argument %q", field.name)
+		}
+	}
+
+	return args, nil
+}
+
+func ArgsUsage[T any](locale i18n.Locale, cmd string) string { // Функция генерирует текст с описанием аргументов команды, тег help содержит ключ сообщения
+	fields := argFields(reflect.TypeOf(*(new(T))))
+
+	var (
+		usage strings.Builder
+		help  strings.Builder
+	)
+
+	usage.WriteString("/" + cmd)
+
+	for _, field := range fields {
+		name := field.name
+		if field.rest {
+			name += "..."
+		}
+
+		if field.required {
+			usage.WriteString(" <" + name + ">")
+		} else {
+			usage.WriteString(" [" + name + "]")
+		}
+
+		if field.help != "" {
+			help.WriteString("\n" + field.name + " - " + i18n.T(locale, field.help))
+		}
+	}
+
+	return usage.String() + help.String()
+}
+
+type argField struct { // Описание поля структуры аргументов
+	name     string
+	help     string
+	required bool
+	rest     bool
+	url      bool
+	index    int
+}
+
+func argFields(t reflect.Type) []argField { // Функция собирает описание полей из тегов структуры
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	fields := make([]argField, 0, t.NumField())
+
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+
+		tag, ok := sf.Tag.Lookup("arg")
+		if !ok || tag == "-" || !sf.IsExported() { // Поля без тега arg не заполняются
+			continue
+		}
+
+		parts := strings.Split(tag, ",")
+		field := argField{
+			name:  parts[0],
+			help:  sf.Tag.Get("help"),
+			index: i,
+		}
+
+		if field.name == "" {
+			field.name = strings.ToLower(sf.Name)
+		}
+
+		for _, opt := range parts[1:] {
+			switch opt {
+			case "required":
+				field.required = true
+			case "rest":
+				field.rest = true
+			case "url":
+				field.url = true
+			}
+		}
+
+		fields = append(fields, field)
+	}
+
+	return fields
+}
+
+func parseTextArgs(src string, v reflect.Value, fields []argField, set map[string]bool) error { // Функция для разбора позиционных аргументов и аргументов вида key=value
+	tokens, err := splitArgs(src)
+	if err != nil {
+		return err
+	}
+
+	var positional []string
+
+	for _, token := range tokens {
+		if token.eq > 0 { // Аргумент вида key=value
+			key := strings.ToLower(token.text[:token.eq])
+
+			if field, ok := findArgField(fields, key); ok {
+				if set[field.name] {
+					return fmt.Errorf("argument %q is set twice", field.name)
+				}
+
+				if err := setArgField(v.Field(field.index), field.name, token.text[token.eq+1:]); err != nil {
+					return err
+				}
+
+				set[field.name] = true
+				continue
+			}
+		}
+
+		positional = append(positional, token.text)
+	}
+
+	var rest []string
+	for _, value := range positional { // Ссылки сначала занимают поля с опцией url, так порядок имени и ссылки не важен
+		field, ok := nextURLField(fields, set)
+		if !ok || !looksLikeURL(value) {
+			rest = append(rest, value)
+			continue
+		}
+
+		if err := setArgField(v.Field(field.index), field.name, value); err != nil {
+			return err
+		}
+
+		set[field.name] = true
+	}
+	positional = rest
+
+	for _, field := range fields { // Позиционные аргументы заполняют оставшиеся поля по порядку
+		if len(positional) == 0 {
+			break
+		}
+
+		if set[field.name] {
+			continue
+		}
+
+		value := positional[0]
+		positional = positional[1:]
+
+		if field.rest { // Поле забирает все оставшиеся аргументы
+			value = strings.Join(append([]string{value}, positional...), " ")
+			positional = nil
+		}
+
+		if err := setArgField(v.Field(field.index), field.name, value); err != nil {
+			return err
+		}
+
+		set[field.name] = true
+	}
+
+	if len(positional) > 0 {
+		return fmt.Errorf("unexpected arguments: %s", strings.Join(positional, " "))
+	}
+
+	return nil
+}
+
+func parseJSONArgs(src string, v reflect.Value, fields []argField, set map[string]bool) error { // Функция для разбора аргументов переданных JSON объектом
+	var raw map[string]json.RawMessage
+
+	if err := json.Unmarshal([]byte(src), &raw); err != nil {
+		return err
+	}
+
+	for key, value := range raw {
+		field, ok := findArgField(fields, strings.ToLower(key))
+		if !ok {
+			return fmt.Errorf("unknown argument %q", key)
+		}
+
+		var str string
+		if err := json.Unmarshal(value, &str); err == nil { // Строковые значения разбираются так же как текстовые аргументы
+			if err := setArgField(v.Field(field.index), field.name, str); err != nil {
+				return err
+			}
+		} else if err := json.Unmarshal(value, v.Field(field.index).Addr().Interface()); err != nil {
+			return fmt.Errorf("invalid value for argument %q: %w", field.name, err)
+		}
+
+		set[field.name] = true
+	}
+
+	return nil
+}
+
+func nextURLField(fields []argField, set map[string]bool) (argField, bool) { // Функция возвращает первое незаполненное поле с опцией url
+	for _, field := range fields {
+		if field.url && !set[field.name] {
+			return field, true
+		}
+	}
+
+	return argField{}, false
+}
+
+func looksLikeURL(value string) bool {
+	value = strings.ToLower(value)
+
+	return strings.HasPrefix(value, "http://") || strings.HasPrefix(value, "https://")
+}
+
+func findArgField(fields []argField, name string) (argField, bool) {
+	for _, field := range fields {
+		if strings.ToLower(field.name) == name {
+			return field, true
+		}
+	}
+
+	return argField{}, false
+}
+
+func setArgField(field reflect.Value, name string, value string) error { // Функция для записи строкового значения в поле нужного типа
+	if field.Type() == reflect.TypeOf(time.Duration(0)) {
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return fmt.Errorf("invalid value for argument %q: %w", name, err)
+		}
+		field.SetInt(int64(d))
+		return nil
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(value)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(value, 10, field.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("invalid value for argument %q: %w", name, err)
+		}
+		field.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(value, 10, field.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("invalid value for argument %q: %w", name, err)
+		}
+		field.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(value, field.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("invalid value for argument %q: %w", name, err)
+		}
+		field.SetFloat(f)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("invalid value for argument %q: %w", name, err)
+		}
+		field.SetBool(b)
+	default:
+		return fmt.Errorf("unsupported type %s of argument %q", field.Type(), name)
+	}
+
+	return nil
+}
+
+type argToken struct { // Один аргумент после разбиения строки
+	text string
+	eq   int // Позиция первого знака = вне кавычек, -1 если его нет
+}
+
+var (
+	errUnterminatedQuote = errors.New("unterminated quote in arguments")
+
+	argQuotes = map[rune]rune{ // Открывающие кавычки и соответствующие им закрывающие, телеграм на телефонах часто подменяет "" на «» и “”
+		'"':  '"',
+		'\'': '\'',
+		'«':  '»',
+		'“':  '”',
+	}
+)
+
+func SplitArgs(src string) ([]string, error) { // Функция разбивает строку на слова по тем же правилам кавычек что и ParseArgs, для команд со свободным текстом
+	tokens, err := splitArgs(src)
+	if err != nil {
+		return nil, err
+	}
+
+	words := make([]string, 0, len(tokens))
+	for _, token := range tokens {
+		words = append(words, token.text)
+	}
+
+	return words, nil
+}
+
+func splitArgs(src string) ([]argToken, error) { // Функция разбивает строку на аргументы с учетом кавычек
+	var (
+		tokens  []argToken
+		current strings.Builder
+		quote   rune // Открытая кавычка, 0 если мы вне кавычек
+		escaped bool
+		started bool // В текущем аргументе уже есть символы (в том числе пустые кавычки)
+		eq      = -1
+	)
+
+	flush := func() {
+		if started {
+			tokens = append(tokens, argToken{text: current.String(), eq: eq})
+		}
+		current.Reset()
+		started = false
+		eq = -1
+	}
+
+	for _, r := range src {
+		switch {
+		case escaped:
+			current.WriteRune(r)
+			escaped = false
+		case r == '\\' && quote != '\'':
+			escaped = true
+			started = true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				current.WriteRune(r)
+			}
+		case argQuotes[r] != 0 && (r != '\'' || !started || (eq >= 0 && eq == current.Len()-1)): // Апостроф внутри слова не кавычка: O'Reilly
+			quote = argQuotes[r]
+			started = true
+		case r == ' ' || r == '\t' || r == '\n':
+			flush()
+		default:
+			if r == '=' && eq == -1 {
+				eq = current.Len()
+			}
+			current.WriteRune(r)
+			started = true
+		}
+	}
+
+	if quote != 0 || escaped {
+		return nil, errUnterminatedQuote
+	}
+
+	flush()
+
+	return tokens, nil
+}
@@ -0,0 +1,129 @@
+package botkit
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+type testSourceArgs struct {
+	Name string `arg:"name" help:"args.source_name"`
+	URL  string `arg:"url,url" help:"args.source_url"`
+}
+
+type testEditArgs struct {
+	ID   int64  `arg:"id,required"`
+	Name string `arg:"name"`
+	URL  string `arg:"url,url"`
+}
+
+type testTypedArgs struct {
+	Count   int           `arg:"n"`
+	Every   time.Duration `arg:"every"`
+	Enabled bool          `arg:"enabled"`
+	Query   string        `arg:"query,rest"`
+}
+
+func TestParseArgsSource(t *testing.T) {
+	tests := []struct {
+		name    string
+		src     string
+		want    testSourceArgs
+		wantErr bool
+	}{
+		{name: "empty", src: "", want: testSourceArgs{}},
+		{name: "name and url", src: "Golang https://go.dev/blog/feed.atom", want: testSourceArgs{Name: "Golang", URL: "https://go.dev/blog/feed.atom"}},
+		{name: "url only", src: "https://go.dev/blog/feed.atom", want: testSourceArgs{URL: "https://go.dev/blog/feed.atom"}},
+		{name: "url before name", src: "https://go.dev/blog/feed.atom Golang", want: testSourceArgs{Name: "Golang", URL: "https://go.dev/blog/feed.atom"}},
+		{name: "name only", src: "Golang", want: testSourceArgs{Name: "Golang"}},
+		{name: "quoted name", src: `"Go Blog" https://go.dev/blog/feed.atom`, want: testSourceArgs{Name: "Go Blog", URL: "https://go.dev/blog/feed.atom"}},
+		{name: "guillemets", src: "«Go Blog» https://go.dev/blog/feed.atom", want: testSourceArgs{Name: "Go Blog", URL: "https://go.dev/blog/feed.atom"}},
+		{name: "apostrophe inside word", src: "O'Reilly https://www.oreilly.com/radar/feed/", want: testSourceArgs{Name: "O'Reilly", URL: "https://www.oreilly.com/radar/feed/"}},
+		{name: "apostrophe inside quoted name", src: `"O'Reilly Radar" https://www.oreilly.com/radar/feed/`, want: testSourceArgs{Name: "O'Reilly Radar", URL: "https://www.oreilly.com/radar/feed/"}},
+		{name: "single quoted name", src: "'Go Blog' https://go.dev/blog/feed.atom", want: testSourceArgs{Name: "Go Blog", URL: "https://go.dev/blog/feed.atom"}},
+		{name: "single quoted value", src: "name='Go Blog'", want: testSourceArgs{Name: "Go Blog"}},
+		{name: "key value", src: `url=https://go.dev/blog/feed.atom name="Go Blog"`, want: testSourceArgs{Name: "Go Blog", URL: "https://go.dev/blog/feed.atom"}},
+		{name: "escaped quote", src: `"Go \"Blog\""`, want: testSourceArgs{Name: `Go "Blog"`}},
+		{name: "json", src: `{"name":"Go Blog","url":"https://go.dev/blog/feed.atom"}`, want: testSourceArgs{Name: "Go Blog", URL: "https://go.dev/blog/feed.atom"}},
+		{name: "unterminated quote", src: `"Go Blog https://go.dev/blog/feed.atom`, wantErr: true},
+		{name: "unterminated single quote", src: "'Go Blog", wantErr: true},
+		{name: "too many arguments", src: "Golang https://go.dev/blog/feed.atom extra", wantErr: true},
+		{name: "set twice", src: "name=Go name=Blog", wantErr: true},
+		{name: "unknown json field", src: `{"title":"Go"}`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseArgs[testSourceArgs](tt.src)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseArgs(%q) error = %v, wantErr %v", tt.src, err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("ParseArgs(%q) = %+v, want %+v", tt.src, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseArgsEdit(t *testing.T) {
+	tests := []struct {
+		name    string
+		src     string
+		want    testEditArgs
+		wantErr bool
+	}{
+		{name: "id and name", src: "5 Golang", want: testEditArgs{ID: 5, Name: "Golang"}},
+		{name: "id and url", src: "5 https://go.dev/blog/feed.atom", want: testEditArgs{ID: 5, URL: "https://go.dev/blog/feed.atom"}},
+		{name: "all", src: "5 Golang https://go.dev/blog/feed.atom", want: testEditArgs{ID: 5, Name: "Golang", URL: "https://go.dev/blog/feed.atom"}},
+		{name: "key value id", src: "name=Golang id=5", want: testEditArgs{ID: 5, Name: "Golang"}},
+		{name: "missing required", src: "", wantErr: true},
+		{name: "invalid id", src: "five Golang", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseArgs[testEditArgs](tt.src)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseArgs(%q) error = %v, wantErr %v", tt.src, err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("ParseArgs(%q) = %+v, want %+v", tt.src, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseArgsTypes(t *testing.T) {
+	tests := []struct {
+		name    string
+		src     string
+		want    testTypedArgs
+		wantErr bool
+	}{
+		{name: "rest", src: "10 1h true go generics", want: testTypedArgs{Count: 10, Every: time.Hour, Enabled: true, Query: "go generics"}},
+		{name: "rest set by key", src: "query=x 1 1m false extra", wantErr: true},
+		{name: "json numbers", src: `{"n":3,"enabled":true,"every":"30s"}`, want: testTypedArgs{Count: 3, Every: 30 * time.Second, Enabled: true}},
+		{name: "invalid int", src: "n=many", wantErr: true},
+		{name: "invalid duration", src: "every=soon", wantErr: true},
+		{name: "invalid bool", src: "enabled=maybe", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseArgs[testTypedArgs](tt.src)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseArgs(%q) error = %v, wantErr %v", tt.src, err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseArgs(%q) = %+v, want %+v", tt.src, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestArgsUsage(t *testing.T) {
+	got := ArgsUsage[testEditArgs]("en", "edit")
+	if want := "/edit <id> [name] [url]"; got != want {
+		t.Errorf("ArgsUsage() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,18 @@
+package botkit
+
+import (
+	"strconv"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/speeddem0n/GoNewsBot/internal/i18n"
+)
+
+const SaveArticleCallback = "save" // Префикс callback_data кнопки сохранения статьи в закладки, данные кнопки содержат ID статьи
+
+func SaveArticleButton(label string, articleID int64) tgbotapi.InlineKeyboardButton { // Функция создает кнопку сохранения статьи в закладки
+	return tgbotapi.NewInlineKeyboardButtonData(label, CallbackData(SaveArticleCallback, strconv.FormatInt(articleID, 10)))
+}
+
+func SaveArticleKeyboard(locale i18n.Locale, articleID int64) tgbotapi.InlineKeyboardMarkup { // Функция создает клавиатуру с кнопкой "Сохранить" для сообщения со статьей
+	return tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(SaveArticleButton(i18n.T(locale, SaveArticleButtonMsg), articleID)))
+}
@@ -3,102 +3,306 @@ package botkit
 import (
 	"context"
 	"runtime/debug"
+	"sync"
 	"time"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 	"github.com/sirupsen/logrus"
 	"github.com/speeddem0n/GoNewsBot/internal/botkit/markup"
+	"github.com/speeddem0n/GoNewsBot/internal/i18n"
+	"github.com/speeddem0n/GoNewsBot/internal/models"
 )
 
 type Bot struct { // Структура для тг бота
-	api      *tgbotapi.BotAPI
-	cmdViews map[string]ViewFunc // Мап для ViewFunc (В качестве кюча испольльзуется команда для бота)
+	api           *tgbotapi.BotAPI     // Клиент для получения апдейтов и настройки бота
+	messenger     Messenger            // Клиент который передается во View для ответов пользователям
+	cmdViews      map[string]ViewFunc  // Мап для ViewFunc (В качестве кюча испольльзуется команда для бота)
+	callbackViews map[string]ViewFunc  // View для нажатий inline кнопок (В качестве ключа используется префикс callback_data)
+	conversations *ConversationManager // Активные диалоги пользователей, сюда направляются сообщения которые не являются командами
+	middlewares   []Middleware         // Глобальные middleware, применяются к каждому апдейту
+	memberView    ViewFunc             // View для апдейтов об изменении участников чатов (my_chat_member, chat_member)
+	inlineView    ViewFunc             // View для inline запросов, если не задана inline запросы игнорируются
+	commands      []Command            // Описание зарегистрированных команд для меню и /help
+	roles         RoleResolver         // Определяет роль пользователя для команд с Command.Role
+
+	workers         int           // Количество воркеров которые параллельно обрабатывают апдейты
+	shutdownTimeout time.Duration // Сколько ждать завершения обработки уже полученных апдейтов при остановке бота
 }
 
 // addsource (команда для добавления источников в бд)
 // listsources (команда для получения списка источников)
 // deletesource (команда для удаления) источника
-type ViewFunc func(ctx context.Context, bot *tgbotapi.BotAPI, update tgbotapi.Update) error // Функция которая будет реагировать на определенную команду
+type ViewFunc func(ctx context.Context, bot Messenger, update tgbotapi.Update) error // Функция которая будет реагировать на определенную команду
 
 /* tgbotapi.Update любой ивент который приходит от телеграма при взаимодействии с ботом
-bot *tgbotapi.BotAPI клиет для доступа к боту */
+bot Messenger клиет для доступа к боту, в тестах вместо телеграма можно передать botkittest.FakeMessenger */
 
-func NewBot(api *tgbotapi.BotAPI) *Bot { // конструктор для структуры бота
+func NewBot(
+	api *tgbotapi.BotAPI,
+	messenger Messenger,
+	conversations *ConversationManager,
+	roles RoleResolver,
+	workers int,
+	shutdownTimeout time.Duration,
+) *Bot { // конструктор для структуры бота
 	return &Bot{
-		api: api,
+		api:             api,
+		messenger:       messenger,
+		conversations:   conversations,
+		roles:           roles,
+		workers:         max(workers, 1),
+		shutdownTimeout: shutdownTimeout,
 	}
 }
 
 func (b *Bot) Start(ctx context.Context) error { // Метод для запуска бота
 	u := tgbotapi.NewUpdate(0) // Устанавливаем канал в который будут писаться сообщения
 	u.Timeout = 60             // Устанавка таймаута на 60 секунд
+	u.AllowedUpdates = allowedUpdates
+
+	if _, err := b.api.Request(tgbotapi.DeleteWebhookConfig{}); err != nil { // Пока у бота установлен webhook, телеграм не отдает апдейты через long polling
+		return err
+	}
+
+	b.setCommandsOnStart() // Обновляем меню команд в телеграме
 
 	updates := b.api.GetUpdatesChan(u) // Получаем сам chan
+	defer b.api.StopReceivingUpdates() // Останавливаем long polling при выходе
+
+	return b.serve(ctx, updates)
+}
+
+func (b *Bot) serve(ctx context.Context, updates <-chan tgbotapi.Update) error { // Метод распределяет апдейты по воркерам, апдейты одного чата всегда попадают к одному воркеру и обрабатываются по порядку
+	var (
+		wg         sync.WaitGroup
+		queues     = make([]chan tgbotapi.Update, b.workers) // Очередь апдейтов для каждого воркера
+		handlerCtx = context.WithoutCancel(ctx)              // Остановка бота не прерывает уже начатую обработку, время обработки ограничивает middleware
+	)
+
+	for i := range queues {
+		queues[i] = make(chan tgbotapi.Update, workerQueueSize)
+
+		wg.Add(1)
+		go func(queue <-chan tgbotapi.Update) { // Запускаем воркер
+			defer wg.Done()
+
+			for update := range queue {
+				b.handleUpdate(handlerCtx, update) // Вызываем метод handleUpdate
+			}
+		}(queues[i])
+	}
 
 	for {
 		select {
-		case update := <-updates: // Кейс, когда получаем апдейт из канала updates
-			updateCtx, updateCancel := context.WithTimeout(ctx, 5*time.Second) // Создаем новый контекст для обработки апдейта с таймаутом 5 секунд
+		case update, ok := <-updates: // Кейс, когда получаем апдейт из канала updates
+			if !ok { // Источник апдейтов закрыт
+				b.drain(queues, &wg)
+				return nil
+			}
 
-			b.handleUpdate(updateCtx, update) // Вызываем метод handleUpdate
-			updateCancel()                    // Отменяем контекст
+			select {
+			case queues[workerIndex(update, b.workers)] <- update: // Если очередь воркера заполнена, ждем пока он освободится
+			case <-ctx.Done():
+				logrus.Warnf("bot is stopping, update %d dropped", update.UpdateID)
+			}
 		case <-ctx.Done(): // Кейс когда контекст завершен
+			b.drain(queues, &wg)
 			return ctx.Err()
 		}
+	}
+}
+
+func (b *Bot) drain(queues []chan tgbotapi.Update, wg *sync.WaitGroup) { // Метод закрывает очереди и ждет пока воркеры обработают уже полученные апдейты
+	for _, queue := range queues {
+		close(queue)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		logrus.Info("all updates handled")
+	case <-time.After(b.shutdownTimeout):
+		logrus.Warnf("updates are still being handled after %s, stopping anyway", b.shutdownTimeout)
+	}
+}
+
+const workerQueueSize = 64 // Размер очереди апдейтов одного воркера
+
+var allowedUpdates = []string{ // Типы апдейтов которые бот получает от телеграма, chat_member телеграм присылает только если запросить его явно
+	tgbotapi.UpdateTypeMessage,
+	tgbotapi.UpdateTypeCallbackQuery,
+	tgbotapi.UpdateTypeInlineQuery,
+	tgbotapi.UpdateTypeMyChatMember,
+	tgbotapi.UpdateTypeChatMember,
+}
+
+func workerIndex(update tgbotapi.Update, workers int) int { // Функция выбирает воркер по ID чата, чтобы апдейты одного чата обрабатывались по порядку
+	var key int64
 
+	switch {
+	case update.CallbackQuery != nil && update.CallbackQuery.Message == nil: // Нажатие кнопки под inline сообщением, чата у него нет
+		key = update.CallbackQuery.From.ID
+	case update.FromChat() != nil:
+		key = update.FromChat().ID
+	case update.MyChatMember != nil:
+		key = update.MyChatMember.Chat.ID
+	case update.ChatMember != nil:
+		key = update.ChatMember.Chat.ID
+	case update.SentFrom() != nil: // Например inline запросы, у них нет чата
+		key = update.SentFrom().ID
 	}
+
+	return int(uint64(key) % uint64(workers))
+}
+
+func (b *Bot) Use(middlewares ...Middleware) { // Метод для добавления middleware которые применяются ко всем апдейтам
+	b.middlewares = append(b.middlewares, middlewares...)
 }
 
-func (b *Bot) RegisterCmdView(cmd string, view ViewFunc) { // Метод для регистрации View
+func (b *Bot) RegisterCmdView(cmd Command, view ViewFunc, middlewares ...Middleware) { // Метод для регистрации View, middlewares применяются только к этой команде
 	if b.cmdViews == nil { // Проверка, инициализирована ли мапа
 		b.cmdViews = make(map[string]ViewFunc) // Если нет то инициализируем
 	}
 
-	b.cmdViews[cmd] = view // Добовляем команду в мапу
+	if cmd.Role != models.RoleNone { // Проверка роли выполняется до остальных middleware команды
+		middlewares = append([]Middleware{b.requireRole(cmd.Role)}, middlewares...)
+	}
+
+	if _, exists := b.cmdViews[cmd.Name]; !exists {
+		b.commands = append(b.commands, cmd)
+	} else {
+		for i := range b.commands { // Повторная регистрация заменяет описание команды
+			if b.commands[i].Name == cmd.Name {
+				b.commands[i] = cmd
+			}
+		}
+	}
+
+	b.cmdViews[cmd.Name] = Chain(view, middlewares...) // Добовляем команду в мапу
+}
+
+func (b *Bot) RegisterChatMemberView(view ViewFunc) { // Метод для регистрации View которая получает апдейты об изменении участников чатов
+	b.memberView = view
+}
+
+func (b *Bot) RegisterInlineView(view ViewFunc, middlewares ...Middleware) { // Метод для регистрации View которая отвечает на inline запросы, у бота должен быть включен inline режим в @BotFather
+	b.inlineView = Chain(view, middlewares...)
+}
+
+func (b *Bot) Group(middlewares ...Middleware) *Group { // Метод для создания группы команд с общими middleware
+	return &Group{
+		bot:         b,
+		middlewares: middlewares,
+	}
 }
 
 func (b *Bot) handleUpdate(ctx context.Context, update tgbotapi.Update) { // Метод для обработки tgbotapi.Update и направления их на соответствующие ViewFunc(комманды)
-	defer func() { // В ViewFunc может произойти паника, отлавливаем ее с помощью recover() и логируем
+	defer func() { // Последний рубеж, если паника не была перехвачена middleware, отлавливаем ее с помощью recover() и логируем
 		if p := recover(); p != nil {
 			logrus.Errorf("panic recovered: %v\n%s", p, string(debug.Stack()))
 		}
 	}()
 
-	if update.Message == nil || !update.Message.IsCommand() && update.CallbackQuery == nil { // Проверяем не пустое ли сообщение и является ли оно коммандой
-		errReply := tgbotapi.NewMessage(update.Message.Chat.ID, MsgIsNotACommand) // Подготавливаем сообщение MsgIsNotACommand
-		errReply.ParseMode = "MarkdownV2"
-		if _, err := b.api.Send(errReply); err != nil { // Отправляем сообщение о некорректном вводе пользователю
-			logrus.Errorf("Failed to send message to user: %s", err)
+	if update.MyChatMember != nil || update.ChatMember != nil { // Изменились участники чата, сообщения пользователю тут нет
+		if b.memberView == nil {
 			return
 		}
+
+		if err := b.memberView(ctx, b.messenger, update); err != nil {
+			logrus.Errorf("failed to handle chat member update: %v", err)
+		}
 		return
 	}
 
-	var view ViewFunc // Переменная для ViewFunc
+	var view ViewFunc
 
-	cmd := update.Message.Command() // Достаем команду из сообщения
+	switch {
+	case update.Message != nil:
+		view = b.resolveView(update)
+	case update.CallbackQuery != nil:
+		view = b.resolveCallbackView(update)
+	case update.InlineQuery != nil && b.inlineView != nil:
+		view = b.inlineView
+	default: // Остальные апдейты не обрабатываем
+		return
+	}
 
-	cmdView, ok := b.cmdViews[cmd] // Пробуем достать View из мапы
-	if !ok {
-		errReply := tgbotapi.NewMessage(update.Message.Chat.ID, markup.EscapeForMarkdown(InvalidCommandMsg)) // Подготавливаем сообщение MsgIsNotACommand
-		errReply.ParseMode = "MarkdownV2"
-		if _, err := b.api.Send(errReply); err != nil { // Отправляем сообщение о некорректном вводе пользователю
-			logrus.Errorf("Failed to send message to user: %s", err)
-			return
-		}
+	view = Chain(view, b.middlewares...) // Глобальные middleware оборачивают любой обработчик
 
-		return // Просто выходим если нет такой команды
+	if err := view(ctx, b.messenger, update); err != nil { // Вызываем view и обробатываем ошибку
+		logrus.Errorf("failed to handle update: %v", err)
+		b.replyInternalError(update)
 	}
+}
 
-	view = cmdView
+func (b *Bot) replyInternalError(update tgbotapi.Update) { // Метод сообщает пользователю об ошибке, язык чата тут уже неизвестен, поэтому берем язык пользователя
+	text := i18n.T(userLocale(update), InternalErrorMsg)
 
-	if err := view(ctx, b.api, update); err != nil { // Вызываем view и обробатываем ошибку
-		logrus.Errorf("failed to handle update: %v", err)
+	if update.InlineQuery != nil { // Inline запрос не из чата, отвечать некуда, пользователь просто не увидит результатов
+		return
+	}
 
-		if _, err := b.api.Send( // Отправляем пользователю сообщение об ошибке
-			tgbotapi.NewMessage(update.Message.Chat.ID, "internal error"),
-		); err != nil {
-			logrus.Errorf("failed to send message: %v", err)
+	if update.CallbackQuery != nil { // На нажатие кнопки отвечаем всплывающим уведомлением
+		callback := tgbotapi.NewCallbackWithAlert(update.CallbackQuery.ID, text)
+		if err := b.messenger.AnswerCallback(callback); err != nil {
+			logrus.Errorf("failed to answer callback: %v", err)
 		}
+		return
+	}
+
+	if _, err := b.messenger.Send(tgbotapi.NewMessage(update.Message.Chat.ID, text)); err != nil {
+		logrus.Errorf("failed to send message: %v", err)
+	}
+}
+
+func (b *Bot) resolveView(update tgbotapi.Update) ViewFunc { // Метод для выбора ViewFunc которая обработает сообщение
+	if !update.Message.IsCommand() { // Сообщение не является командой
+		return b.viewMessage
 	}
+
+	cmdView, ok := b.cmdViews[update.Message.Command()] // Пробуем достать View из мапы
+	if !ok {
+		return viewUnknownCommand
+	}
+
+	return cmdView
+}
+
+func (b *Bot) viewMessage(ctx context.Context, bot Messenger, update tgbotapi.Update) error { // View для сообщений которые не являются командами
+	handled, err := b.conversations.Handle(ctx, bot, update) // Сообщение может быть ответом на вопрос активного диалога
+	if err != nil || handled {
+		return err
+	}
+
+	errReply := tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(i18n.FromContext(ctx), MsgIsNotACommand)) // Подготавливаем сообщение MsgIsNotACommand
+	errReply.ParseMode = "MarkdownV2"
+	if _, err := bot.Send(errReply); err != nil { // Отправляем сообщение о некорректном вводе пользователю
+		return err
+	}
+
+	return nil
+}
+
+func viewUnknownCommand(ctx context.Context, bot Messenger, update tgbotapi.Update) error { // View для команд которые не зарегистрированы
+	errReply := tgbotapi.NewMessage(update.Message.Chat.ID, markup.EscapeForMarkdown(i18n.T(i18n.FromContext(ctx), InvalidCommandMsg))) // Подготавливаем сообщение InvalidCommandMsg
+	errReply.ParseMode = "MarkdownV2"
+	if _, err := bot.Send(errReply); err != nil { // Отправляем сообщение о некорректном вводе пользователю
+		return err
+	}
+
+	return nil
+}
+
+func userLocale(update tgbotapi.Update) i18n.Locale { // Функция возвращает язык из настроек телеграма пользователя
+	if user := update.SentFrom(); user != nil {
+		locale, _ := i18n.Parse(user.LanguageCode)
+		return locale
+	}
+
+	return i18n.Default
 }
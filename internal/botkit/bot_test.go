@@ -0,0 +1,266 @@
+package botkit_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"github.com/speeddem0n/GoNewsBot/internal/botkit"
+	"github.com/speeddem0n/GoNewsBot/internal/botkit/botkittest"
+	"github.com/speeddem0n/GoNewsBot/internal/botkit/markup"
+	"github.com/speeddem0n/GoNewsBot/internal/i18n"
+	"github.com/speeddem0n/GoNewsBot/internal/models"
+)
+
+type fakeRoles map[int64]models.Role
+
+func (r fakeRoles) RoleOf(ctx context.Context, userID int64) (models.Role, error) {
+	return r[userID], nil
+}
+
+func reply(text string) botkit.ViewFunc {
+	return func(ctx context.Context, bot botkit.Messenger, update tgbotapi.Update) error {
+		_, err := bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, text))
+		return err
+	}
+}
+
+func TestCommandRole(t *testing.T) {
+	const (
+		viewer = 1
+		admin  = 2
+		owner  = 3
+		user   = 4
+	)
+
+	messenger := botkittest.NewFakeMessenger()
+	bot := botkit.NewBot(nil, messenger, botkit.NewConversationManager(time.Minute), fakeRoles{
+		viewer: models.RoleViewer,
+		admin:  models.RoleAdmin,
+		owner:  models.RoleOwner,
+	}, 1, time.Second)
+
+	bot.RegisterCmdView(botkit.Command{Name: "usage", Role: models.RoleAdmin}, reply("ok"))
+	bot.RegisterCmdView(botkit.Command{Name: "list", Role: models.RoleViewer}, reply("ok"))
+	bot.RegisterCmdView(botkit.Command{Name: "help"}, reply("ok"))
+
+	denied := i18n.T(i18n.Russian, botkit.AccessDeniedMsg)
+
+	tests := []struct {
+		userID int64
+		text   string
+		want   string
+	}{
+		{userID: admin, text: "/usage", want: "ok"},
+		{userID: owner, text: "/usage", want: "ok"},
+		{userID: viewer, text: "/usage", want: denied},
+		{userID: user, text: "/usage", want: denied},
+		{userID: viewer, text: "/list", want: "ok"},
+		{userID: user, text: "/list", want: denied},
+		{userID: user, text: "/help", want: "ok"},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s by %d", tt.text, tt.userID), func(t *testing.T) {
+			messenger.Reset()
+
+			bot.HandleUpdate(context.Background(), botkittest.MessageUpdate(tt.userID, tt.userID, tt.text))
+
+			sent := messenger.Sent()
+			if len(sent) != 1 || sent[0].Text != tt.want {
+				t.Errorf("sent %v, want one message %q", sent, tt.want)
+			}
+		})
+	}
+}
+
+func TestMiddlewareOrder(t *testing.T) {
+	var calls []string
+
+	trace := func(name string) botkit.Middleware {
+		return func(next botkit.ViewFunc) botkit.ViewFunc {
+			return func(ctx context.Context, bot botkit.Messenger, update tgbotapi.Update) error {
+				calls = append(calls, name)
+				return next(ctx, bot, update)
+			}
+		}
+	}
+
+	messenger := botkittest.NewFakeMessenger()
+	bot := botkit.NewBot(nil, messenger, botkit.NewConversationManager(time.Minute), fakeRoles{}, 1, time.Second)
+	bot.Use(trace("global"))
+
+	group := bot.Group(trace("group"))
+	group.RegisterCmdView(botkit.Command{Name: "ping"}, func(ctx context.Context, bot botkit.Messenger, update tgbotapi.Update) error {
+		calls = append(calls, "view")
+		return nil
+	}, trace("command"))
+
+	bot.HandleUpdate(context.Background(), botkittest.MessageUpdate(1, 1, "/ping"))
+
+	if got, want := fmt.Sprint(calls), "[global group command view]"; got != want {
+		t.Errorf("calls = %s, want %s", got, want)
+	}
+}
+
+func TestHandleUpdateErrors(t *testing.T) {
+	messenger := botkittest.NewFakeMessenger()
+	bot := botkit.NewBot(nil, messenger, botkit.NewConversationManager(time.Minute), fakeRoles{}, 1, time.Second)
+
+	bot.RegisterCmdView(botkit.Command{Name: "fail"}, func(ctx context.Context, bot botkit.Messenger, update tgbotapi.Update) error {
+		return errors.New("storage is down")
+	})
+	bot.RegisterCmdView(botkit.Command{Name: "panic"}, func(ctx context.Context, bot botkit.Messenger, update tgbotapi.Update) error {
+		panic("unexpected")
+	})
+
+	tests := []struct {
+		text string
+		want string
+	}{
+		{text: "/fail", want: i18n.T(i18n.Russian, botkit.InternalErrorMsg)},
+		{text: "/unknown", want: markup.EscapeForMarkdown(i18n.T(i18n.Russian, botkit.InvalidCommandMsg))},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			messenger.Reset()
+
+			bot.HandleUpdate(context.Background(), botkittest.MessageUpdate(1, 1, tt.text))
+
+			sent, ok := messenger.LastSent()
+			if !ok || sent.Text != tt.want {
+				t.Errorf("sent %q, want %q", sent.Text, tt.want)
+			}
+		})
+	}
+
+	t.Run("/panic", func(t *testing.T) {
+		bot.HandleUpdate(context.Background(), botkittest.MessageUpdate(1, 1, "/panic")) // Паника не должна уронить тест
+	})
+}
+
+func TestConversation(t *testing.T) {
+	messenger := botkittest.NewFakeMessenger()
+	conversations := botkit.NewConversationManager(time.Minute)
+	bot := botkit.NewBot(nil, messenger, conversations, fakeRoles{}, 1, time.Second)
+
+	var answers botkit.ConversationAnswers
+
+	bot.RegisterCmdView(botkit.Command{Name: "add"}, conversations.Begin(&botkit.Conversation{
+		Steps: []botkit.ConversationStep{
+			{Key: "url", Prompt: botkit.AskSourceURLMsg},
+			{Key: "name", Prompt: botkit.AskSourceNameMsg, Validate: func(answer string) error {
+				if answer == "" {
+					return i18n.NewError(botkit.EmptyAnswerMsg)
+				}
+				return nil
+			}},
+		},
+		Done: func(ctx context.Context, bot botkit.Messenger, update tgbotapi.Update, got botkit.ConversationAnswers) error {
+			answers = got
+			_, err := bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, "done"))
+			return err
+		},
+	}))
+	bot.RegisterCmdView(botkit.Command{Name: "cancel"}, conversations.ViewCancel())
+
+	ctx := context.Background()
+
+	bot.HandleUpdate(ctx, botkittest.MessageUpdate(1, 1, "/add"))
+	bot.HandleUpdate(ctx, botkittest.MessageUpdate(2, 2, "https://other.example/feed")) // Диалоги разных пользователей не смешиваются
+	bot.HandleUpdate(ctx, botkittest.MessageUpdate(1, 1, "https://go.dev/blog/feed.atom"))
+	bot.HandleUpdate(ctx, botkittest.MessageUpdate(1, 1, "Go Blog"))
+
+	if answers["url"] != "https://go.dev/blog/feed.atom" || answers["name"] != "Go Blog" {
+		t.Errorf("answers = %v", answers)
+	}
+
+	sent := messenger.Sent()
+	if len(sent) != 4 {
+		t.Fatalf("sent %d messages, want 4: %v", len(sent), sent)
+	}
+	if sent[1].Chat.ID != 2 || sent[1].Text != i18n.T(i18n.Russian, botkit.MsgIsNotACommand) {
+		t.Errorf("message without conversation = %q in chat %d", sent[1].Text, sent[1].Chat.ID)
+	}
+	if sent[3].Text != "done" {
+		t.Errorf("last message = %q, want done", sent[3].Text)
+	}
+
+	messenger.Reset()
+	answers = nil
+
+	bot.HandleUpdate(ctx, botkittest.MessageUpdate(1, 1, "/add"))
+	bot.HandleUpdate(ctx, botkittest.MessageUpdate(1, 1, "/cancel"))
+	bot.HandleUpdate(ctx, botkittest.MessageUpdate(1, 1, "Go Blog"))
+
+	if answers != nil {
+		t.Errorf("canceled conversation finished with %v", answers)
+	}
+	if sent, _ := messenger.LastSent(); sent.Text != i18n.T(i18n.Russian, botkit.MsgIsNotACommand) {
+		t.Errorf("message after cancel = %q", sent.Text)
+	}
+}
+
+func TestStart(t *testing.T) {
+	server := botkittest.NewServer()
+	defer server.Close()
+
+	api, err := server.BotAPI()
+	if err != nil {
+		t.Fatalf("BotAPI() error = %v", err)
+	}
+
+	bot := botkit.NewBot(api, botkit.NewTelegramMessenger(api), botkit.NewConversationManager(time.Minute), fakeRoles{}, 4, time.Second)
+	bot.RegisterCmdView(botkit.Command{Name: "echo", Description: botkit.CmdHelpDescription}, func(ctx context.Context, bot botkit.Messenger, update tgbotapi.Update) error {
+		_, err := bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, update.Message.CommandArguments()))
+		return err
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() { done <- bot.Start(ctx) }()
+
+	const count = 10
+	for i := range count {
+		server.PushUpdate(botkittest.MessageUpdate(7, 7, fmt.Sprintf("/echo %d", i)))
+	}
+
+	sent, ok := server.WaitSent(count, 5*time.Second)
+	if !ok {
+		t.Fatalf("bot sent %d messages, want %d", len(sent), count)
+	}
+
+	for i, message := range sent { // Апдейты одного чата обрабатываются по порядку даже с несколькими воркерами
+		if message.Chat.ID != 7 || message.Text != fmt.Sprint(i) {
+			t.Errorf("message %d = %q in chat %d", i, message.Text, message.Chat.ID)
+		}
+	}
+
+	cancel()
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Start() error = %v, want context.Canceled", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Start() did not stop after cancel")
+	}
+
+	methods := make(map[string]bool)
+	for _, request := range server.Requests() {
+		methods[request.Method] = true
+	}
+	for _, method := range []string{"deleteWebhook", "setMyCommands", "getUpdates", "sendMessage"} {
+		if !methods[method] {
+			t.Errorf("bot did not call %s", method)
+		}
+	}
+}
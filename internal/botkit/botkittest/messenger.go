@@ -0,0 +1,219 @@
+package botkittest
+
+import (
+	"sync"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"github.com/speeddem0n/GoNewsBot/internal/botkit"
+)
+
+var _ botkit.Messenger = (*FakeMessenger)(nil)
+
+type DeletedMessage struct { // Удаленное сообщение
+	ChatID    int64
+	MessageID int
+}
+
+type FakeMessenger struct { // Реализация botkit.Messenger в памяти, запоминает все вызовы
+	Err error // Если задана, все методы возвращают эту ошибку
+
+	mu        sync.Mutex
+	lastID    int
+	sent      []tgbotapi.Message
+	documents []tgbotapi.DocumentConfig
+	edited    []tgbotapi.EditMessageTextConfig
+	deleted   []DeletedMessage
+	callbacks []tgbotapi.CallbackConfig
+	inline    []tgbotapi.InlineConfig
+	admins    map[int64][]tgbotapi.ChatMember
+}
+
+func NewFakeMessenger() *FakeMessenger { // Конструктор для структуры FakeMessenger
+	return &FakeMessenger{
+		admins: make(map[int64][]tgbotapi.ChatMember),
+	}
+}
+
+func (m *FakeMessenger) Send(msg tgbotapi.MessageConfig) (tgbotapi.Message, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.Err != nil {
+		return tgbotapi.Message{}, m.Err
+	}
+
+	m.lastID++
+
+	sent := tgbotapi.Message{
+		MessageID:   m.lastID,
+		Chat:        &tgbotapi.Chat{ID: msg.ChatID},
+		Date:        int(time.Now().Unix()),
+		Text:        msg.Text,
+		ReplyMarkup: inlineKeyboard(msg.ReplyMarkup),
+	}
+	m.sent = append(m.sent, sent)
+
+	return sent, nil
+}
+
+func (m *FakeMessenger) SendDocument(doc tgbotapi.DocumentConfig) (tgbotapi.Message, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.Err != nil {
+		return tgbotapi.Message{}, m.Err
+	}
+
+	m.lastID++
+	m.documents = append(m.documents, doc)
+
+	return tgbotapi.Message{
+		MessageID: m.lastID,
+		Chat:      &tgbotapi.Chat{ID: doc.ChatID},
+		Date:      int(time.Now().Unix()),
+		Caption:   doc.Caption,
+	}, nil
+}
+
+func (m *FakeMessenger) Edit(edit tgbotapi.EditMessageTextConfig) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.Err != nil {
+		return m.Err
+	}
+
+	m.edited = append(m.edited, edit)
+
+	return nil
+}
+
+func (m *FakeMessenger) Delete(chatID int64, messageID int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.Err != nil {
+		return m.Err
+	}
+
+	m.deleted = append(m.deleted, DeletedMessage{ChatID: chatID, MessageID: messageID})
+
+	return nil
+}
+
+func (m *FakeMessenger) ChatAdministrators(chatID int64) ([]tgbotapi.ChatMember, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.Err != nil {
+		return nil, m.Err
+	}
+
+	return append([]tgbotapi.ChatMember(nil), m.admins[chatID]...), nil
+}
+
+func (m *FakeMessenger) AnswerCallback(callback tgbotapi.CallbackConfig) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.Err != nil {
+		return m.Err
+	}
+
+	m.callbacks = append(m.callbacks, callback)
+
+	return nil
+}
+
+func (m *FakeMessenger) AnswerInline(inline tgbotapi.InlineConfig) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.Err != nil {
+		return m.Err
+	}
+
+	m.inline = append(m.inline, inline)
+
+	return nil
+}
+
+func (m *FakeMessenger) SetAdministrators(chatID int64, members ...tgbotapi.ChatMember) { // Метод задает список администраторов чата
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.admins[chatID] = members
+}
+
+func (m *FakeMessenger) Sent() []tgbotapi.Message { // Метод возвращает отправленные сообщения в порядке отправки
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([]tgbotapi.Message(nil), m.sent...)
+}
+
+func (m *FakeMessenger) LastSent() (tgbotapi.Message, bool) { // Метод возвращает последнее отправленное сообщение
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if len(m.sent) == 0 {
+		return tgbotapi.Message{}, false
+	}
+
+	return m.sent[len(m.sent)-1], true
+}
+
+func (m *FakeMessenger) Documents() []tgbotapi.DocumentConfig { // Метод возвращает отправленные файлы, содержимое лежит в поле File
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([]tgbotapi.DocumentConfig(nil), m.documents...)
+}
+
+func (m *FakeMessenger) Edited() []tgbotapi.EditMessageTextConfig {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([]tgbotapi.EditMessageTextConfig(nil), m.edited...)
+}
+
+func (m *FakeMessenger) Deleted() []DeletedMessage {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([]DeletedMessage(nil), m.deleted...)
+}
+
+func (m *FakeMessenger) Callbacks() []tgbotapi.CallbackConfig {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([]tgbotapi.CallbackConfig(nil), m.callbacks...)
+}
+
+func (m *FakeMessenger) InlineAnswers() []tgbotapi.InlineConfig {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([]tgbotapi.InlineConfig(nil), m.inline...)
+}
+
+func (m *FakeMessenger) Reset() { // Метод очищает запомненные вызовы
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.sent, m.documents, m.edited, m.deleted, m.callbacks, m.inline = nil, nil, nil, nil, nil, nil
+}
+
+func inlineKeyboard(markup any) *tgbotapi.InlineKeyboardMarkup { // Функция возвращает inline клавиатуру сообщения, другие клавиатуры в Message не сохраняются
+	switch keyboard := markup.(type) {
+	case tgbotapi.InlineKeyboardMarkup:
+		return &keyboard
+	case *tgbotapi.InlineKeyboardMarkup:
+		return keyboard
+	default:
+		return nil
+	}
+}
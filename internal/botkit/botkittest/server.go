@@ -0,0 +1,276 @@
+package botkittest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+const (
+	Token       = "test-token" // Токен бота который принимает Server
+	maxPollWait = time.Second  // Сколько getUpdates ждет новых апдейтов, чтобы бот быстро останавливался в тестах
+
+	maxUploadSize = 10 << 20 // Максимальный размер файла отправляемого ботом
+)
+
+type Request struct { // Запрос к Bot API который получил Server
+	Method string
+	Params url.Values
+}
+
+type Server struct { // Локальная замена Bot API для запуска бота целиком, например botkit.Bot.Start
+	*httptest.Server
+
+	mu       sync.Mutex
+	notify   chan struct{} // Закрывается и пересоздается при добавлении апдейта, будит ожидающий getUpdates
+	updates  []tgbotapi.Update
+	requests []Request
+	sent     []tgbotapi.Message
+	admins   map[int64][]tgbotapi.ChatMember
+	lastID   int
+}
+
+func NewServer() *Server { // Конструктор для структуры Server, сервер сразу запускается и должен быть остановлен методом Close
+	s := &Server{
+		notify: make(chan struct{}),
+		admins: make(map[int64][]tgbotapi.ChatMember),
+	}
+
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+
+	return s
+}
+
+func (s *Server) Endpoint() string { // Метод возвращает шаблон адреса для tgbotapi.NewBotAPIWithAPIEndpoint
+	return s.URL + "/bot%s/%s"
+}
+
+func (s *Server) BotAPI() (*tgbotapi.BotAPI, error) { // Метод создает клиент tgbotapi который ходит в Server
+	return tgbotapi.NewBotAPIWithAPIEndpoint(Token, s.Endpoint())
+}
+
+func (s *Server) PushUpdate(updates ...tgbotapi.Update) { // Метод добавляет апдейты которые бот получит через getUpdates
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.updates = append(s.updates, updates...)
+
+	close(s.notify)
+	s.notify = make(chan struct{})
+}
+
+func (s *Server) SetAdministrators(chatID int64, members ...tgbotapi.ChatMember) { // Метод задает ответ getChatAdministrators
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.admins[chatID] = members
+}
+
+func (s *Server) Requests() []Request { // Метод возвращает все полученные запросы в порядке получения
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]Request(nil), s.requests...)
+}
+
+func (s *Server) Sent() []tgbotapi.Message { // Метод возвращает сообщения отправленные ботом через sendMessage и sendDocument
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]tgbotapi.Message(nil), s.sent...)
+}
+
+func (s *Server) WaitSent(count int, timeout time.Duration) ([]tgbotapi.Message, bool) { // Метод ждет пока бот отправит count сообщений
+	deadline := time.Now().Add(timeout)
+
+	for {
+		if sent := s.Sent(); len(sent) >= count {
+			return sent, true
+		}
+
+		if time.Now().After(deadline) {
+			return s.Sent(), false
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/") // Адрес запроса имеет вид /bot<token>/<method>
+	if len(parts) != 2 || parts[0] != "bot"+Token {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	if err := r.ParseMultipartForm(maxUploadSize); err != nil && !errors.Is(err, http.ErrNotMultipart) { // Файлы tgbotapi отправляет в multipart/form-data
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	method := parts[1]
+
+	s.mu.Lock()
+	s.requests = append(s.requests, Request{Method: method, Params: r.Form})
+	s.mu.Unlock()
+
+	switch method {
+	case "getMe":
+		writeResult(w, tgbotapi.User{ID: 1, IsBot: true, FirstName: "Test", UserName: "test_bot"})
+	case "getUpdates":
+		writeResult(w, s.pollUpdates(r))
+	case "sendMessage":
+		message, err := s.sendMessage(r.Form)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		writeResult(w, message)
+	case "sendDocument":
+		message, err := s.sendDocument(r)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		writeResult(w, message)
+	case "getChatAdministrators":
+		chatID, _ := strconv.ParseInt(r.Form.Get("chat_id"), 10, 64)
+
+		s.mu.Lock()
+		admins := s.admins[chatID]
+		s.mu.Unlock()
+
+		writeResult(w, append([]tgbotapi.ChatMember{}, admins...))
+	case "editMessageText", "deleteMessage", "answerCallbackQuery", "answerInlineQuery",
+		"setMyCommands", "deleteMyCommands", "setWebhook", "deleteWebhook":
+		writeResult(w, true)
+	default:
+		writeError(w, http.StatusNotFound, "Not Found: method "+method+" is not supported by botkittest.Server")
+	}
+}
+
+func (s *Server) pollUpdates(r *http.Request) []tgbotapi.Update { // Метод отдает апдейты начиная с offset, если их нет ждет как long polling телеграма
+	offset, _ := strconv.Atoi(r.Form.Get("offset"))
+
+	wait := maxPollWait
+	if timeout, err := strconv.Atoi(r.Form.Get("timeout")); err == nil && time.Duration(timeout)*time.Second < wait {
+		wait = time.Duration(timeout) * time.Second
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	for {
+		s.mu.Lock()
+		var pending []tgbotapi.Update
+		for _, update := range s.updates {
+			if update.UpdateID >= offset {
+				pending = append(pending, update)
+			}
+		}
+		notify := s.notify
+		s.mu.Unlock()
+
+		if len(pending) > 0 {
+			return pending
+		}
+
+		select {
+		case <-notify:
+		case <-timer.C:
+			return []tgbotapi.Update{}
+		case <-r.Context().Done():
+			return []tgbotapi.Update{}
+		}
+	}
+}
+
+func (s *Server) sendMessage(form url.Values) (tgbotapi.Message, error) { // Метод сохраняет сообщение отправленное ботом
+	chatID, err := strconv.ParseInt(form.Get("chat_id"), 10, 64)
+	if err != nil {
+		return tgbotapi.Message{}, fmt.Errorf("bad request: invalid chat_id %q", form.Get("chat_id"))
+	}
+
+	var keyboard *tgbotapi.InlineKeyboardMarkup
+	if raw := form.Get("reply_markup"); raw != "" {
+		keyboard = &tgbotapi.InlineKeyboardMarkup{}
+		if err := json.Unmarshal([]byte(raw), keyboard); err != nil || keyboard.InlineKeyboard == nil { // Клавиатуры кроме inline в Message не сохраняются
+			keyboard = nil
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.lastID++
+
+	message := tgbotapi.Message{
+		MessageID:   s.lastID,
+		Chat:        &tgbotapi.Chat{ID: chatID},
+		Date:        int(time.Now().Unix()),
+		Text:        form.Get("text"),
+		ReplyMarkup: keyboard,
+	}
+	s.sent = append(s.sent, message)
+
+	return message, nil
+}
+
+func (s *Server) sendDocument(r *http.Request) (tgbotapi.Message, error) { // Метод сохраняет файл отправленный ботом, в Message сохраняется только имя файла
+	chatID, err := strconv.ParseInt(r.Form.Get("chat_id"), 10, 64)
+	if err != nil {
+		return tgbotapi.Message{}, fmt.Errorf("bad request: invalid chat_id %q", r.Form.Get("chat_id"))
+	}
+
+	if r.MultipartForm == nil || len(r.MultipartForm.File["document"]) == 0 {
+		return tgbotapi.Message{}, errors.New("bad request: there is no document in the request")
+	}
+
+	file := r.MultipartForm.File["document"][0]
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.lastID++
+
+	message := tgbotapi.Message{
+		MessageID: s.lastID,
+		Chat:      &tgbotapi.Chat{ID: chatID},
+		Date:      int(time.Now().Unix()),
+		Caption:   r.Form.Get("caption"),
+		Document: &tgbotapi.Document{
+			FileID:   "document-" + strconv.Itoa(s.lastID),
+			FileName: file.Filename,
+			FileSize: int(file.Size),
+		},
+	}
+	s.sent = append(s.sent, message)
+
+	return message, nil
+}
+
+func writeResult(w http.ResponseWriter, result any) {
+	raw, err := json.Marshal(result)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(tgbotapi.APIResponse{Ok: true, Result: raw})
+}
+
+func writeError(w http.ResponseWriter, code int, description string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(tgbotapi.APIResponse{Ok: false, ErrorCode: code, Description: description})
+}
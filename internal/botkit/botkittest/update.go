@@ -0,0 +1,74 @@
+package botkittest
+
+import (
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+var lastUpdateID atomic.Int64
+
+func MessageUpdate(chatID, userID int64, text string) tgbotapi.Update { // Функция создает апдейт с сообщением пользователя, текст начинающийся с / считается командой
+	message := &tgbotapi.Message{
+		MessageID: int(lastUpdateID.Add(1)),
+		From:      &tgbotapi.User{ID: userID, LanguageCode: "ru"},
+		Chat:      &tgbotapi.Chat{ID: chatID, Type: chatType(chatID, userID)},
+		Date:      int(time.Now().Unix()),
+		Text:      text,
+	}
+
+	if strings.HasPrefix(text, "/") { // Телеграм помечает команду в начале сообщения сущностью bot_command
+		length := len(text)
+		if i := strings.IndexByte(text, ' '); i > 0 {
+			length = i
+		}
+
+		message.Entities = []tgbotapi.MessageEntity{{Type: "bot_command", Offset: 0, Length: length}}
+	}
+
+	return tgbotapi.Update{
+		UpdateID: message.MessageID,
+		Message:  message,
+	}
+}
+
+func CallbackUpdate(chatID, userID int64, messageID int, data string) tgbotapi.Update { // Функция создает апдейт с нажатием inline кнопки под сообщением бота
+	id := int(lastUpdateID.Add(1))
+
+	return tgbotapi.Update{
+		UpdateID: id,
+		CallbackQuery: &tgbotapi.CallbackQuery{
+			ID:   strconv.Itoa(id),
+			From: &tgbotapi.User{ID: userID, LanguageCode: "ru"},
+			Message: &tgbotapi.Message{
+				MessageID: messageID,
+				Chat:      &tgbotapi.Chat{ID: chatID, Type: chatType(chatID, userID)},
+			},
+			Data: data,
+		},
+	}
+}
+
+func InlineQueryUpdate(userID int64, query string) tgbotapi.Update { // Функция создает апдейт с inline запросом, который пользователь набрал в любом чате
+	id := int(lastUpdateID.Add(1))
+
+	return tgbotapi.Update{
+		UpdateID: id,
+		InlineQuery: &tgbotapi.InlineQuery{
+			ID:    strconv.Itoa(id),
+			From:  &tgbotapi.User{ID: userID, LanguageCode: "ru"},
+			Query: query,
+		},
+	}
+}
+
+func chatType(chatID, userID int64) string { // В личных сообщениях ID чата совпадает с ID пользователя
+	if chatID == userID {
+		return "private"
+	}
+
+	return "supergroup"
+}
@@ -0,0 +1,47 @@
+package botkit
+
+import (
+	"context"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+const callbackSeparator = ":" // Разделитель префикса и данных в callback_data, например "search:2"
+
+func CallbackData(prefix string, payload ...string) string { // Функция собирает callback_data для inline кнопки, телеграм ограничивает ее 64 байтами
+	return strings.Join(append([]string{prefix}, payload...), callbackSeparator)
+}
+
+func CallbackPayload(update tgbotapi.Update) []string { // Функция возвращает данные inline кнопки без префикса
+	if update.CallbackQuery == nil {
+		return nil
+	}
+
+	parts := strings.Split(update.CallbackQuery.Data, callbackSeparator)
+
+	return parts[1:]
+}
+
+func (b *Bot) RegisterCallbackView(prefix string, view ViewFunc, middlewares ...Middleware) { // Метод для регистрации View для нажатий inline кнопок с callback_data начинающейся с prefix
+	if b.callbackViews == nil {
+		b.callbackViews = make(map[string]ViewFunc)
+	}
+
+	b.callbackViews[prefix] = Chain(view, middlewares...)
+}
+
+func (b *Bot) resolveCallbackView(update tgbotapi.Update) ViewFunc { // Метод для выбора ViewFunc которая обработает нажатие кнопки
+	prefix, _, _ := strings.Cut(update.CallbackQuery.Data, callbackSeparator)
+
+	view, ok := b.callbackViews[prefix]
+	if !ok {
+		return viewUnknownCallback
+	}
+
+	return view
+}
+
+func viewUnknownCallback(ctx context.Context, bot Messenger, update tgbotapi.Update) error { // View для кнопок без обработчика, например оставшихся от старой версии бота
+	return bot.AnswerCallback(tgbotapi.NewCallback(update.CallbackQuery.ID, ""))
+}
@@ -0,0 +1,114 @@
+package botkit
+
+import (
+	"context"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/sirupsen/logrus"
+
+	"github.com/speeddem0n/GoNewsBot/internal/i18n"
+	"github.com/speeddem0n/GoNewsBot/internal/models"
+)
+
+type CommandScope string // Тип чатов в меню которых показывается команда
+
+const (
+	ScopePrivateChats CommandScope = "all_private_chats"       // Личные сообщения с ботом
+	ScopeGroupChats   CommandScope = "all_group_chats"         // Группы и супергруппы
+	ScopeChatAdmins   CommandScope = "all_chat_administrators" // Администраторы групп
+)
+
+type Command struct { // Описание команды бота
+	Name        string                          // Команда без слэша, например "add"
+	Description string                          // Ключ короткого описания для меню команд и /help, команды без описания в меню не попадают
+	Usage       func(locale i18n.Locale) string // Описание аргументов команды для /help на нужном языке
+	Role        models.Role                     // Роль необходимая для вызова команды, бот сам проверяет ее перед вызовом View
+	Scopes      []CommandScope                  // Типы чатов в меню которых показывается команда, по умолчанию личные сообщения
+}
+
+type RoleResolver interface { // Интерфейс для определения роли пользователя
+	RoleOf(ctx context.Context, userID int64) (models.Role, error)
+}
+
+func (b *Bot) Commands() []Command { // Метод возвращает зарегистрированные команды в порядке регистрации
+	return append([]Command(nil), b.commands...)
+}
+
+func (b *Bot) SetCommands() error { // Метод для установки меню команд в телеграме для каждого типа чатов и каждого языка
+	if err := b.setCommands(i18n.Default, ""); err != nil { // Меню по умолчанию для пользователей с языком без перевода
+		return err
+	}
+
+	for _, locale := range i18n.Locales() {
+		if err := b.setCommands(locale, string(locale)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (b *Bot) setCommands(locale i18n.Locale, languageCode string) error { // Метод для установки меню команд на одном языке, пустой languageCode - меню по умолчанию
+	byScope := make(map[CommandScope][]tgbotapi.BotCommand)
+
+	for _, cmd := range b.commands {
+		if cmd.Description == "" {
+			continue
+		}
+
+		scopes := cmd.Scopes
+		if len(scopes) == 0 {
+			scopes = []CommandScope{ScopePrivateChats}
+		}
+
+		for _, scope := range scopes {
+			byScope[scope] = append(byScope[scope], tgbotapi.BotCommand{
+				Command:     cmd.Name,
+				Description: i18n.T(locale, cmd.Description),
+			})
+		}
+	}
+
+	for _, scope := range []CommandScope{ScopePrivateChats, ScopeGroupChats, ScopeChatAdmins} {
+		botScope := tgbotapi.BotCommandScope{Type: string(scope)}
+
+		var config tgbotapi.Chattable = tgbotapi.NewSetMyCommandsWithScopeAndLanguage(botScope, languageCode, byScope[scope]...)
+		if len(byScope[scope]) == 0 { // Для типов чатов без команд меню очищается
+			config = tgbotapi.DeleteMyCommandsConfig{Scope: &botScope, LanguageCode: languageCode}
+		}
+
+		if _, err := b.api.Request(config); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (b *Bot) setCommandsOnStart() { // Метод для установки меню команд при запуске, ошибка не мешает работе бота
+	if err := b.SetCommands(); err != nil {
+		logrus.Errorf("failed to set bot commands: %v", err)
+	}
+}
+
+func (b *Bot) requireRole(required models.Role) Middleware { // Middleware пропускает к команде только пользователей с ролью не ниже required
+	return func(next ViewFunc) ViewFunc {
+		return func(ctx context.Context, bot Messenger, update tgbotapi.Update) error {
+			var userID int64
+			if user := update.SentFrom(); user != nil {
+				userID = user.ID
+			}
+
+			role, err := b.roles.RoleOf(ctx, userID)
+			if err != nil {
+				return err
+			}
+
+			if role.AtLeast(required) { // Проверяем достаточно ли у пользователя прав
+				return next(ctx, bot, update)
+			}
+
+			return sendPlain(bot, update.Message.Chat.ID, i18n.T(i18n.FromContext(ctx), AccessDeniedMsg))
+		}
+	}
+}
@@ -0,0 +1,218 @@
+package botkit
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"github.com/speeddem0n/GoNewsBot/internal/i18n"
+)
+
+type ConversationAnswers map[string]string // Ответы пользователя на вопросы диалога (Ключ - ConversationStep.Key)
+
+type ConversationStep struct { // Один шаг диалога
+	Key      string                    // Ключ под которым сохраняется ответ пользователя
+	Prompt   string                    // Ключ сообщения с вопросом который бот задает пользователю
+	Validate func(answer string) error // Необязательная проверка ответа, текст ошибки (i18n.Error) отправляется пользователю
+}
+
+type Conversation struct { // Описание многошагового диалога с пользователем
+	Steps   []ConversationStep                                                  // Вопросы задаются по порядку, шаги на которые уже есть ответ пропускаются
+	Prefill func(ctx context.Context, args string) (ConversationAnswers, error) // Необязательная функция для заполнения ответов из аргументов команды
+	Done    ConversationDoneFunc                                                // Вызывается когда получены ответы на все вопросы
+}
+
+type ConversationDoneFunc func(ctx context.Context, bot Messenger, update tgbotapi.Update, answers ConversationAnswers) error
+
+type conversationKey struct { // Диалог ведется отдельно для каждого пользователя в каждом чате
+	chatID int64
+	userID int64
+}
+
+type conversationState struct { // Состояние активного диалога
+	conversation *Conversation
+	answers      ConversationAnswers
+	expires      time.Time // Время после которого диалог считается брошенным
+}
+
+type ConversationManager struct { // Хранилище активных диалогов пользователей
+	ttl    time.Duration
+	mu     sync.Mutex
+	states map[conversationKey]conversationState
+}
+
+func NewConversationManager(ttl time.Duration) *ConversationManager { // Конструктор для структуры ConversationManager
+	return &ConversationManager{
+		ttl:    ttl,
+		states: make(map[conversationKey]conversationState),
+	}
+}
+
+func (m *ConversationManager) Begin(conversation *Conversation) ViewFunc { // View которая начинает диалог с пользователем
+	return func(ctx context.Context, bot Messenger, update tgbotapi.Update) error {
+		var (
+			answers = make(ConversationAnswers)
+			locale  = i18n.FromContext(ctx)
+		)
+
+		if args := strings.TrimSpace(update.Message.CommandArguments()); args != "" && conversation.Prefill != nil { // Если у команды есть аргументы, заполняем ими часть ответов
+			prefilled, err := conversation.Prefill(ctx, args)
+			if err != nil {
+				return sendPlain(bot, update.Message.Chat.ID, i18n.ErrorText(locale, err))
+			}
+
+			for _, step := range conversation.Steps { // Заполненные аргументы проходят ту же проверку что и ответы на вопросы
+				answer, ok := prefilled[step.Key]
+				if !ok {
+					continue
+				}
+
+				if step.Validate != nil {
+					if err := step.Validate(answer); err != nil {
+						return sendPlain(bot, update.Message.Chat.ID, i18n.ErrorText(locale, err))
+					}
+				}
+
+				answers[step.Key] = answer
+			}
+		}
+
+		return m.advance(ctx, bot, update, conversationState{
+			conversation: conversation,
+			answers:      answers,
+		})
+	}
+}
+
+func (m *ConversationManager) Handle(ctx context.Context, bot Messenger, update tgbotapi.Update) (bool, error) { // Метод передает сообщение в активный диалог пользователя, возвращает false если диалога нет
+	key := conversationKeyFor(update)
+
+	state, ok := m.get(key)
+	if !ok {
+		return false, nil
+	}
+
+	step, ok := state.nextStep()
+	if !ok { // Все ответы уже получены, такого состояния быть не должно
+		m.delete(key)
+		return false, nil
+	}
+
+	var (
+		answer = strings.TrimSpace(update.Message.Text)
+		locale = i18n.FromContext(ctx)
+	)
+
+	if step.Validate != nil {
+		if err := step.Validate(answer); err != nil { // Ответ не прошел проверку, повторяем вопрос
+			return true, sendPlain(bot, update.Message.Chat.ID, i18n.ErrorText(locale, err)+"\n\n"+i18n.T(locale, step.Prompt))
+		}
+	}
+
+	state.answers[step.Key] = answer
+
+	return true, m.advance(ctx, bot, update, state)
+}
+
+func (m *ConversationManager) ViewCancel() ViewFunc { // View для команды cancel, отменяет активный диалог
+	return func(ctx context.Context, bot Messenger, update tgbotapi.Update) error {
+		var (
+			key    = conversationKeyFor(update)
+			locale = i18n.FromContext(ctx)
+		)
+
+		if _, ok := m.get(key); !ok {
+			return sendPlain(bot, update.Message.Chat.ID, i18n.T(locale, NothingToCancelMsg))
+		}
+
+		m.delete(key)
+
+		return sendPlain(bot, update.Message.Chat.ID, i18n.T(locale, ConversationCanceledMsg))
+	}
+}
+
+func (m *ConversationManager) advance(ctx context.Context, bot Messenger, update tgbotapi.Update, state conversationState) error { // Метод задает следующий вопрос или завершает диалог
+	key := conversationKeyFor(update)
+
+	step, ok := state.nextStep()
+	if !ok { // Ответы получены на все вопросы
+		m.delete(key)
+		return state.conversation.Done(ctx, bot, update, state.answers)
+	}
+
+	state.expires = time.Now().Add(m.ttl)
+	m.set(key, state)
+
+	locale := i18n.FromContext(ctx)
+
+	return sendPlain(bot, update.Message.Chat.ID, i18n.T(locale, step.Prompt)+"\n\n"+i18n.T(locale, ConversationCancelHint))
+}
+
+func (m *ConversationManager) get(key conversationKey) (conversationState, bool) { // Метод для получения активного диалога, просроченные диалоги удаляются
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	state, ok := m.states[key]
+	if !ok {
+		return conversationState{}, false
+	}
+
+	if time.Now().After(state.expires) {
+		delete(m.states, key)
+		return conversationState{}, false
+	}
+
+	return state, true
+}
+
+func (m *ConversationManager) set(key conversationKey, state conversationState) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	for k, s := range m.states { // Заодно чистим брошенные диалоги других пользователей
+		if now.After(s.expires) {
+			delete(m.states, k)
+		}
+	}
+
+	m.states[key] = state
+}
+
+func (m *ConversationManager) delete(key conversationKey) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.states, key)
+}
+
+func (s conversationState) nextStep() (ConversationStep, bool) { // Метод возвращает первый шаг на который еще нет ответа
+	for _, step := range s.conversation.Steps {
+		if _, ok := s.answers[step.Key]; !ok {
+			return step, true
+		}
+	}
+
+	return ConversationStep{}, false
+}
+
+func conversationKeyFor(update tgbotapi.Update) conversationKey {
+	key := conversationKey{chatID: update.Message.Chat.ID}
+
+	if update.Message.From != nil { // В каналах у сообщения может не быть отправителя
+		key.userID = update.Message.From.ID
+	}
+
+	return key
+}
+
+func sendPlain(bot Messenger, chatID int64, text string) error { // Функция для отправки сообщения без разметки
+	if _, err := bot.Send(tgbotapi.NewMessage(chatID, text)); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,11 @@
+package botkit
+
+import (
+	"context"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+func (b *Bot) HandleUpdate(ctx context.Context, update tgbotapi.Update) { // Обработка одного апдейта без long polling, для тестов с botkittest.FakeMessenger
+	b.handleUpdate(ctx, update)
+}
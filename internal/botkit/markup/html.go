@@ -0,0 +1,13 @@
+package markup
+
+import (
+	"html"
+	"regexp"
+	"strings"
+)
+
+var htmlTag = regexp.MustCompile(`<[^>]*>`) // Регулярка соответствует html тегам, например в описаниях статей из rss
+
+func PlainText(src string) string { // Функция убирает из текста html теги и лишние пробелы
+	return strings.Join(strings.Fields(html.UnescapeString(htmlTag.ReplaceAllString(src, " "))), " ")
+}
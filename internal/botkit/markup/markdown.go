@@ -46,3 +46,9 @@ var ( // Replacer спец сипволоб для markdown сообщений
 func EscapeForMarkdown(src string) string { // Функция для замены спецсимволов markdown в тексте
 	return replacer.Replace(src)
 }
+
+var linkURLReplacer = strings.NewReplacer(`\`, `\\`, `)`, `\)`) // Внутри ссылки MarkdownV2 нужно экранировать только ) и \
+
+func EscapeLinkURL(link string) string { // Функция для экранирования адреса в ссылке MarkdownV2
+	return linkURLReplacer.Replace(link)
+}
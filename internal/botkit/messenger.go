@@ -0,0 +1,74 @@
+package botkit
+
+import (
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+type Messenger interface { // Интерфейс клиента телеграма, View и воркеры работают только через него
+	Send(msg tgbotapi.MessageConfig) (tgbotapi.Message, error)          // Отправка сообщения
+	SendDocument(doc tgbotapi.DocumentConfig) (tgbotapi.Message, error) // Отправка файла
+	Edit(edit tgbotapi.EditMessageTextConfig) error                     // Изменение текста и клавиатуры отправленного сообщения
+	Delete(chatID int64, messageID int) error                           // Удаление сообщения
+	ChatAdministrators(chatID int64) ([]tgbotapi.ChatMember, error)     // Список администраторов чата или канала
+	AnswerCallback(callback tgbotapi.CallbackConfig) error              // Ответ на нажатие inline кнопки
+	AnswerInline(inline tgbotapi.InlineConfig) error                    // Ответ на inline запрос (@bot запрос в любом чате)
+}
+
+type TelegramMessenger struct { // Реализация Messenger поверх tgbotapi
+	api *tgbotapi.BotAPI
+}
+
+func NewTelegramMessenger(api *tgbotapi.BotAPI) *TelegramMessenger { // Конструктор для структуры TelegramMessenger
+	return &TelegramMessenger{api: api}
+}
+
+func (m *TelegramMessenger) Send(msg tgbotapi.MessageConfig) (tgbotapi.Message, error) {
+	return m.api.Send(msg)
+}
+
+func (m *TelegramMessenger) SendDocument(doc tgbotapi.DocumentConfig) (tgbotapi.Message, error) {
+	return m.api.Send(doc)
+}
+
+func (m *TelegramMessenger) Edit(edit tgbotapi.EditMessageTextConfig) error {
+	// Для сообщений отправленных через inline режим телеграм возвращает true вместо сообщения, поэтому используем Request
+	if _, err := m.api.Request(edit); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *TelegramMessenger) Delete(chatID int64, messageID int) error {
+	if _, err := m.api.Request(tgbotapi.NewDeleteMessage(chatID, messageID)); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *TelegramMessenger) ChatAdministrators(chatID int64) ([]tgbotapi.ChatMember, error) {
+	return m.api.GetChatAdministrators(
+		tgbotapi.ChatAdministratorsConfig{
+			ChatConfig: tgbotapi.ChatConfig{
+				ChatID: chatID,
+			},
+		},
+	)
+}
+
+func (m *TelegramMessenger) AnswerCallback(callback tgbotapi.CallbackConfig) error {
+	if _, err := m.api.Request(callback); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *TelegramMessenger) AnswerInline(inline tgbotapi.InlineConfig) error {
+	if _, err := m.api.Request(inline); err != nil {
+		return err
+	}
+
+	return nil
+}
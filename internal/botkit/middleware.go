@@ -0,0 +1,27 @@
+package botkit
+
+type Middleware func(next ViewFunc) ViewFunc // Функция которая оборачивает ViewFunc дополнительной логикой
+
+func Chain(view ViewFunc, middlewares ...Middleware) ViewFunc { // Функция оборачивает view в middlewares, первая middleware в списке будет внешней
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		view = middlewares[i](view)
+	}
+
+	return view
+}
+
+type Group struct { // Группа команд с общими middleware
+	bot         *Bot
+	middlewares []Middleware
+}
+
+func (g *Group) RegisterCmdView(cmd Command, view ViewFunc, middlewares ...Middleware) { // Метод для регистрации View с middleware группы
+	g.bot.RegisterCmdView(cmd, Chain(view, middlewares...), g.middlewares...)
+}
+
+func (g *Group) Group(middlewares ...Middleware) *Group { // Метод для создания вложенной группы, она наследует middleware родителя
+	return &Group{
+		bot:         g.bot,
+		middlewares: append(append([]Middleware{}, g.middlewares...), middlewares...),
+	}
+}
@@ -1,15 +1,127 @@
 package botkit
 
+// Ключи сообщений для пользователя, тексты на каждом языке лежат в пакете i18n
 const (
-	InvalidCommandMsg = "Неизветная команда.\nДоступные комманды: /help - Список команд"
-	CommandList       = `/help - Список комманд
-
-	/add {"name":"*Имя источника","url":"*Ссылка на rss ленту источника"} - Добавить новый источник для новостей
-	
-	/list - Вывести список всех источников
-	
-	/delete {"id":*ID источника}`
-	InvalidAddInput    = `Некорректные данные, формат ввода JSON - {"name":"*Имя источника","url":"*Ссылка на rss ленту источника"}`
-	MsgIsNotACommand   = "Я принимаю только команды, /help для отоброжения списка команд\\."
-	InvalidDeleteInput = `Некорректные данные, формат ввода JSON - {"id":*ID источника}`
+	InvalidCommandMsg  = "invalid_command"
+	HelpHeaderMsg      = "help.header"
+	HelpJSONArgsMsg    = "help.json_args"
+	InvalidArgsMsg     = "invalid_args"
+	AccessDeniedMsg    = "access_denied"
+	TooManyRequestsMsg = "too_many_requests"
+	MsgIsNotACommand   = "not_a_command"
+	InternalErrorMsg   = "internal_error"
+	EmptyAnswerMsg     = "empty_answer"
+
+	ConversationCancelHint  = "conversation.cancel_hint"
+	ConversationCanceledMsg = "conversation.canceled"
+	NothingToCancelMsg      = "conversation.nothing_to_cancel"
+
+	AskSourceURLMsg     = "source.ask_url"
+	AskSourceNameMsg    = "source.ask_name"
+	AskSourceIDMsg      = "source.ask_id"
+	AskNewSourceNameMsg = "source.ask_new_name"
+	AskNewSourceURLMsg  = "source.ask_new_url"
+	InvalidSourceURLMsg = "source.invalid_url"
+	InvalidSourceIDMsg  = "source.invalid_id"
+	SourceNotFoundMsg   = "source.not_found"
+	SourceAddedMsg      = "source.added"
+	SourceEditedMsg     = "source.edited"
+	SourceDeletedMsg    = "source.deleted"
+	SourceListMsg       = "source.list"
+	SourceInfoMsg       = "source.info"
+	KeepCurrentValue    = "-" // Ответ пользователя означающий что значение нужно оставить без изменений
+
+	GrantUserRequiredMsg = "roles.user_required"
+	InvalidRoleMsg       = "roles.invalid_role"
+	CantManageRoleMsg    = "roles.cant_manage"
+	RoleGrantedMsg       = "roles.granted"
+	RoleRevokedMsg       = "roles.revoked"
+	NoRoleToRevokeMsg    = "roles.nothing_to_revoke"
+
+	LangCurrentMsg        = "lang.current"
+	LangChangedMsg        = "lang.changed"
+	LangResetMsg          = "lang.reset"
+	UnknownLangMsg        = "lang.unknown"
+	ChannelLangChangedMsg = "lang.channel_changed"
+
+	SubscriptionPrivateOnlyMsg = "subscription.private_only"
+	SourcesEmptyMsg            = "subscription.no_sources"
+	SourcesBrowseMsg           = "subscription.sources"
+	SubscribedMsg              = "subscription.subscribed"
+	AlreadySubscribedMsg       = "subscription.already_subscribed"
+	UnsubscribedMsg            = "subscription.unsubscribed"
+	NotSubscribedMsg           = "subscription.not_subscribed"
+
+	AlertPrivateOnlyMsg = "alert.private_only"
+	AlertAddedMsg       = "alert.added"
+	AlertInvalidMsg     = "alert.invalid"
+	AlertTooLongMsg     = "alert.too_long"
+	AlertLimitMsg       = "alert.limit"
+	AlertListMsg        = "alert.list"
+	AlertListEmptyMsg   = "alert.list_empty"
+	AlertRemovedMsg     = "alert.removed"
+	AlertNotFoundMsg    = "alert.not_found"
+	AlertMatchedMsg     = "alert.matched"
+	AlertUsageMsg       = "alert.usage"
+
+	SearchUsageMsg        = "search.usage"
+	SearchNoResultsMsg    = "search.no_results"
+	SearchInvalidSinceMsg = "search.invalid_since"
+	SearchExpiredMsg      = "search.expired"
+
+	PageMsg        = "pagination.page"
+	PrevPageButton = "pagination.prev"
+	NextPageButton = "pagination.next"
+
+	LatestHeaderMsg        = "latest.header"
+	LatestEmptyMsg         = "latest.empty"
+	SourceHealthOKMsg      = "source.health_ok"
+	SourceHealthFailingMsg = "source.health_failing"
+	SourceHealthUnknownMsg = "source.health_unknown"
+	SourceStatsMsg         = "source.stats"
+	SourceArticlesMsg      = "source.articles"
+	SourceNoArticlesMsg    = "source.no_articles"
+
+	SaveArticleButtonMsg    = "bookmark.save_button"
+	BookmarkSavedMsg        = "bookmark.saved"
+	BookmarkAlreadySavedMsg = "bookmark.already_saved"
+	BookmarkRemovedMsg      = "bookmark.removed"
+	BookmarksPrivateOnlyMsg = "bookmark.private_only"
+	BookmarksHeaderMsg      = "bookmark.header"
+	BookmarksEmptyMsg       = "bookmark.empty"
+	BookmarksHintMsg        = "bookmark.hint"
+	BookmarksExportTitle    = "bookmark.export_title"
+	BookmarksUsageMsg       = "bookmark.usage"
+
+	UsageHeaderMsg         = "usage.header"
+	UsageBudgetMsg         = "usage.budget"
+	UsageBudgetExceededMsg = "usage.budget_exceeded"
+	UsageNoBudgetMsg       = "usage.no_budget"
+	UsageMonthMsg          = "usage.month"
+	UsageTotalMsg          = "usage.total"
+	UsageDaysMsg           = "usage.days"
+	UsageSourcesMsg        = "usage.sources"
+	UsageChatsMsg          = "usage.chats"
+	UsageEmptyMsg          = "usage.empty"
+
+	ReadMoreMsg = "article.read_more"
+
+	CmdHelpDescription        = "cmd.help"
+	CmdCancelDescription      = "cmd.cancel"
+	CmdListDescription        = "cmd.list"
+	CmdAddDescription         = "cmd.add"
+	CmdEditDescription        = "cmd.edit"
+	CmdDeleteDescription      = "cmd.delete"
+	CmdGrantDescription       = "cmd.grant"
+	CmdRevokeDescription      = "cmd.revoke"
+	CmdLangDescription        = "cmd.lang"
+	CmdSourcesDescription     = "cmd.sources"
+	CmdSubscribeDescription   = "cmd.subscribe"
+	CmdUnsubscribeDescription = "cmd.unsubscribe"
+	CmdAlertDescription       = "cmd.alert"
+	CmdSearchDescription      = "cmd.search"
+	CmdLatestDescription      = "cmd.latest"
+	CmdSourceDescription      = "cmd.source"
+	CmdSavedDescription       = "cmd.saved"
+	CmdUsageDescription       = "cmd.usage"
 )
@@ -0,0 +1,131 @@
+package botkit
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	secretTokenHeader  = "X-Telegram-Bot-Api-Secret-Token" // Заголовок в котором телеграм передает секрет указанный при установке webhook
+	maxWebhookBodySize = 1 << 20                           // Ограничение на размер тела запроса с апдейтом
+)
+
+type WebhookConfig struct { // Настройки получения апдейтов через webhook
+	ListenAddr  string // Адрес на котором запускается HTTP сервер, например ":8080"
+	Path        string // Путь по которому HTTP сервер принимает апдейты
+	URL         string // Публичный адрес webhook, если он пустой setWebhook не вызывается (например при локальной отладке)
+	SecretToken string // Секрет который телеграм передает в заголовке X-Telegram-Bot-Api-Secret-Token
+}
+
+func (b *Bot) StartWebhook(ctx context.Context, cfg WebhookConfig) error { // Метод для запуска бота в режиме webhook
+	if cfg.URL != "" {
+		if err := b.setWebhook(cfg); err != nil {
+			return err
+		}
+	}
+
+	b.setCommandsOnStart() // Обновляем меню команд в телеграме
+
+	serveCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		updates   = make(chan tgbotapi.Update)
+		serverErr = make(chan error, 1)
+		mux       = http.NewServeMux()
+	)
+
+	path := cfg.Path
+	if path == "" {
+		path = "/"
+	}
+	mux.Handle(path, b.WebhookHandler(serveCtx, cfg.SecretToken, updates))
+
+	server := &http.Server{
+		Addr:              cfg.ListenAddr,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() { // Запускаем HTTP сервер, если он не смог запуститься останавливаем бота
+		logrus.Infof("webhook server listening on %s%s", cfg.ListenAddr, path)
+
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			serverErr <- err
+			cancel()
+		}
+	}()
+
+	go func() { // При остановке бота перестаем принимать новые апдейты
+		<-serveCtx.Done()
+
+		shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), b.shutdownTimeout)
+		defer shutdownCancel()
+
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			logrus.Errorf("failed to shutdown webhook server: %v", err)
+		}
+	}()
+
+	err := b.serve(serveCtx, updates)
+
+	select {
+	case srvErr := <-serverErr:
+		return srvErr
+	default:
+		return err
+	}
+}
+
+func (b *Bot) WebhookHandler(ctx context.Context, secretToken string, updates chan<- tgbotapi.Update) http.Handler { // Метод возвращает HTTP обработчик который принимает апдейты и передает их в updates
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+
+		if secretToken != "" && subtle.ConstantTimeCompare([]byte(r.Header.Get(secretTokenHeader)), []byte(secretToken)) != 1 { // Проверяем что запрос пришел от телеграма
+			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+			return
+		}
+
+		var update tgbotapi.Update
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxWebhookBodySize)).Decode(&update); err != nil {
+			http.Error(w, "invalid update: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		select {
+		case updates <- update: // Апдейт принят, телеграм не будет присылать его повторно
+			w.WriteHeader(http.StatusOK)
+		case <-ctx.Done(): // Бот останавливается, телеграм повторит апдейт позже
+			http.Error(w, http.StatusText(http.StatusServiceUnavailable), http.StatusServiceUnavailable)
+		case <-r.Context().Done():
+		}
+	})
+}
+
+func (b *Bot) setWebhook(cfg WebhookConfig) error { // Метод для установки webhook, в tgbotapi нет поддержки secret_token поэтому запрос собирается вручную
+	params := make(tgbotapi.Params)
+	params["url"] = cfg.URL
+	params.AddNonEmpty("secret_token", cfg.SecretToken)
+
+	if err := params.AddInterface("allowed_updates", allowedUpdates); err != nil {
+		return err
+	}
+
+	if _, err := b.api.MakeRequest("setWebhook", params); err != nil {
+		return err
+	}
+
+	logrus.Infof("webhook set to %s", cfg.URL)
+
+	return nil
+}
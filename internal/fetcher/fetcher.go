@@ -2,6 +2,7 @@ package fetcher
 
 import (
 	"context"
+	"errors"
 	"strings"
 	"sync"
 	"time"
@@ -12,11 +13,16 @@ import (
 )
 
 type ArticleStorage interface { // interface Article для работы со слоем Article бд
-	Store(ctx context.Context, article models.Article) error
+	Store(ctx context.Context, article models.Article) (int64, error)
+}
+
+type ArticleAlerts interface { // interface для связи с пакетом alert, проверяет новые статьи по алертам пользователей
+	Check(ctx context.Context, articles []models.Article) error
 }
 
 type SourceProvider interface { // interface SourceProvider для работы со слоем Source бд
 	Sources(ctx context.Context) ([]models.Source, error)
+	SetFetchStatus(ctx context.Context, id int64, fetchErr error) error
 }
 
 type Source interface { // interface для связи со слоем fetcher/fetch
@@ -28,6 +34,7 @@ type Source interface { // interface для связи со слоем fetcher/f
 type Fetcher struct {
 	articles ArticleStorage // interface Article для работы со слоем Article бд
 	sources  SourceProvider // interface SourceProvider для работы со слоем Source бд
+	alerts   ArticleAlerts  // Алерты пользователей по ключевым словам
 
 	fetchInterval  time.Duration // Интервал с которым мы будем проходить по источникам и собирать статьи
 	filterKeywords []string      // Ключевые слова по которым мы будем фильтровать статьи
@@ -36,12 +43,14 @@ type Fetcher struct {
 func NewFetcher( // Конструктор для структуры Fetcher
 	articleStorage ArticleStorage,
 	sources SourceProvider,
+	alerts ArticleAlerts,
 	fetchInterval time.Duration,
 	filterKeywords []string,
 ) *Fetcher {
 	return &Fetcher{
 		articles:       articleStorage,
 		sources:        sources,
+		alerts:         alerts,
 		fetchInterval:  fetchInterval,
 		filterKeywords: filterKeywords,
 	}
@@ -84,6 +93,7 @@ func (f *Fetcher) Fetch(ctx context.Context) error { // Метод Fetch про
 			defer wg.Done()
 
 			items, err := source.Fetch(ctx) // Достаем статью из источника методом Fetch()
+			f.setFetchStatus(ctx, source, err)
 			if err != nil {
 				logrus.Errorf("An error occured while fetching items from source %q: %v", source.Name(), err)
 				return
@@ -102,7 +112,19 @@ func (f *Fetcher) Fetch(ctx context.Context) error { // Метод Fetch про
 	return nil // Возвращаем нил в слуае успеха
 }
 
+func (f *Fetcher) setFetchStatus(ctx context.Context, source Source, fetchErr error) { // Метод сохраняет результат загрузки для /source, ошибка сохранения не мешает обработке статей
+	if ctx.Err() != nil { // Загрузка прервана остановкой бота, источник тут не виноват
+		return
+	}
+
+	if err := f.sources.SetFetchStatus(ctx, source.ID(), fetchErr); err != nil {
+		logrus.Errorf("Failed to save fetch status of source %q: %v", source.Name(), err)
+	}
+}
+
 func (f *Fetcher) processItems(ctx context.Context, source Source, items []models.Item) error { // Метод для добавления статьи в БД
+	var stored []models.Article // Статьи которых раньше не было в БД
+
 	for _, item := range items {
 		item.Date = item.Date.UTC()
 
@@ -110,18 +132,26 @@ func (f *Fetcher) processItems(ctx context.Context, source Source, items []model
 			continue
 		}
 
-		if err := f.articles.Store(ctx, models.Article{ // Методом articles.Store сохраняем статью в БД
+		article := models.Article{
 			SourceID:  source.ID(),
 			Title:     item.Title,
 			Link:      item.Link,
 			Summary:   item.Summary,
 			Published: item.Date,
-		}); err != nil {
-			return err
+		}
+
+		id, err := f.articles.Store(ctx, article) // Методом articles.Store сохраняем статью в БД
+		if err != nil {
+			return errors.Join(err, f.alerts.Check(ctx, stored)) // Уже сохраненные статьи не попадут в следующий проход, поэтому алерты по ним проверяются сейчас
+		}
+
+		if id != 0 {
+			article.ID = id
+			stored = append(stored, article)
 		}
 	}
 
-	return nil // Возвращаем нил в случае успеха
+	return f.alerts.Check(ctx, stored) // Алерты проверяются сразу, не дожидаясь публикации статьи в канал
 }
 
 func (f *Fetcher) itemShouldbeSkipped(item models.Item) bool { // Метод для проверки, нужно ли пропускать статью
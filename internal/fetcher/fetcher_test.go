@@ -0,0 +1,57 @@
+package fetcher
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/speeddem0n/GoNewsBot/internal/models"
+)
+
+type failingArticles struct { // Хранилище которое падает на статье с заданной ссылкой
+	failLink string
+	nextID   int64
+}
+
+func (s *failingArticles) Store(ctx context.Context, article models.Article) (int64, error) {
+	if article.Link == s.failLink {
+		return 0, errors.New("connection reset")
+	}
+
+	s.nextID++
+
+	return s.nextID, nil
+}
+
+type recordingAlerts struct{ checked []models.Article }
+
+func (a *recordingAlerts) Check(ctx context.Context, articles []models.Article) error {
+	a.checked = append(a.checked, articles...)
+	return nil
+}
+
+type testSource struct{}
+
+func (testSource) ID() int64                                        { return 1 }
+func (testSource) Name() string                                     { return "Go Blog" }
+func (testSource) Fetch(ctx context.Context) ([]models.Item, error) { return nil, nil }
+
+func TestProcessItemsChecksAlertsOnStoreError(t *testing.T) {
+	var (
+		alerts = &recordingAlerts{}
+		f      = NewFetcher(&failingArticles{failLink: "https://go.dev/blog/broken"}, nil, alerts, 0, nil)
+	)
+
+	err := f.processItems(context.Background(), testSource{}, []models.Item{
+		{Title: "Go 1.24", Link: "https://go.dev/blog/go1.24"},
+		{Title: "Broken", Link: "https://go.dev/blog/broken"},
+		{Title: "Go 1.25", Link: "https://go.dev/blog/go1.25"},
+	})
+	if err == nil {
+		t.Fatal("processItems() error = nil, want store error")
+	}
+
+	if len(alerts.checked) != 1 || alerts.checked[0].Link != "https://go.dev/blog/go1.24" {
+		t.Errorf("alerts checked %+v, want only the article stored before the error", alerts.checked)
+	}
+}
@@ -0,0 +1,138 @@
+package i18n
+
+var en = map[string]string{ // Сообщения на английском языке
+	"invalid_command":   "Unknown command.\nAvailable commands: /help - List of commands",
+	"help.header":       "Available commands:",
+	"help.json_args":    `Command arguments can also be passed as a JSON object, e.g. /delete {"id":5}`,
+	"invalid_args":      "Invalid command arguments. Usage:\n\n%s",
+	"access_denied":     "You are not allowed to run this command",
+	"too_many_requests": "Too many requests, please try again a bit later.",
+	"not_a_command":     "I only accept commands, send /help to see the list of commands\\.",
+	"internal_error":    "Internal error, please try again later.",
+	"empty_answer":      "The answer can't be empty.",
+
+	"conversation.cancel_hint":       "/cancel - cancel",
+	"conversation.canceled":          "Cancelled.",
+	"conversation.nothing_to_cancel": "Nothing to cancel.",
+
+	"source.ask_url":      "Send the RSS feed URL of the source.",
+	"source.ask_name":     "Now send the display name of the source.",
+	"source.ask_id":       "Send the source ID. Use /list to see all sources.",
+	"source.ask_new_name": `Send the new source name or "-" to keep the current one.`,
+	"source.ask_new_url":  `Send the new RSS feed URL or "-" to keep the current one.`,
+	"source.invalid_url":  "Invalid URL, expected something like https://example.com/feed.xml",
+	"source.invalid_id":   "The source ID must be a positive number.",
+	"source.not_found":    "There is no source with this ID.",
+	"source.added":        "Source added with ID: `%d`\\. Use this ID to manage the source\\.",
+	"source.edited":       "Source with ID: `%d` updated\\.\n\n%s",
+	"source.deleted":      "Source with ID: `%d` deleted\\.",
+	"source.list":         "Sources \\(%d total\\):\n\n%s",
+	"source.info":         "🌎 *%s*\nID: `%d`\nFeed URL: %s",
+
+	"roles.user_required":     "Specify the user ID or reply with the command to a message of the user.",
+	"roles.invalid_role":      "Unknown role, available roles: owner, editor, viewer.",
+	"roles.cant_manage":       "You can't grant or revoke this role.",
+	"roles.granted":           "User %d now has the %s role.",
+	"roles.revoked":           "User %d no longer has a granted role.",
+	"roles.nothing_to_revoke": "User %d has no granted role.",
+
+	"lang.current":         "Language of this chat: %s. Available languages: %s.\n/lang en - change the language, /lang auto - use the language from Telegram settings.",
+	"lang.changed":         "Chat language changed to %s.",
+	"lang.reset":           "The bot now uses the language from your Telegram settings.",
+	"lang.unknown":         "Unknown language, available languages: %s.",
+	"lang.channel_changed": "Channel posts language changed to %s.",
+
+	"subscription.private_only":       "Subscriptions are available only in private messages with the bot.",
+	"subscription.no_sources":         "There are no sources yet.",
+	"subscription.sources":            "Sources (✅ - subscribed):\n\n%s\n\n/subscribe ID - subscribe, /unsubscribe ID - unsubscribe",
+	"subscription.subscribed":         "You are subscribed to %s, new articles will be sent to this chat.",
+	"subscription.already_subscribed": "You are already subscribed to %s.",
+	"subscription.unsubscribed":       "You are unsubscribed from %s.",
+	"subscription.not_subscribed":     "You are not subscribed to %s.",
+
+	"alert.private_only": "Alerts are configured in private messages with the bot, notifications are sent there too.",
+	"alert.added":        "Alert %d added: %s\nI will send new matching articles here.",
+	"alert.invalid":      "Can't parse the expression: %s\n\n%s",
+	"alert.too_long":     "The expression is too long, at most %d characters.",
+	"alert.limit":        "You already have %d alerts, remove some with /alert remove ID.",
+	"alert.list":         "Your alerts:\n\n%s\n\n/alert remove ID - remove",
+	"alert.list_empty":   "You have no alerts. Add one: /alert add CVE openssl",
+	"alert.removed":      "Alert %d removed.",
+	"alert.not_found":    "You have no alert with ID %d.",
+	"alert.matched":      "🔔 Alert matched: %s\n\n%s\n%s",
+	"alert.usage":        "/alert add <expression> - add an alert\n/alert list - list alerts\n/alert remove <ID> - remove an alert\n\nAll space separated words must match, OR or | - any of the conditions, -word - the word must not appear, \"phrase\" is matched as a whole, parentheses group conditions.\nFor example: CVE (openssl | nginx) -windows",
+
+	"search.usage":         "/search <query> - search articles\n\nFilters:\nsource:5, source:habr or source:\"Hacker News\" - only articles of the source (ID or part of the name)\nsince:24h, since:7d, since:2w or since:2025-01-31 - only articles published after the given time\n\nFor example: /search go generics source:habr since:30d",
+	"search.no_results":    "Nothing found.",
+	"search.invalid_since": "Can't parse the filter since:%s. Examples: since:24h, since:7d, since:2w, since:2025-01-31",
+	"search.expired":       "The search results are outdated, run /search again.",
+
+	"pagination.page": "Page %d",
+	"pagination.prev": "◀️ Back",
+	"pagination.next": "Next ▶️",
+
+	"latest.header":         "🆕 Latest articles",
+	"latest.empty":          "There are no articles yet.",
+	"source.health_ok":      "✅ Fetched without errors, last fetch %s",
+	"source.health_failing": "⚠️ Failed fetches in a row: %d, last attempt %s\nError: %s",
+	"source.health_unknown": "❔ Not fetched yet",
+	"source.stats":          "Articles: %d, posted to the channel: %d, the newest from %s",
+	"source.articles":       `Latest articles \(✅ posted, 🕓 waiting to be posted\):`,
+	"source.no_articles":    `There are no articles from this source yet\.`,
+
+	"bookmark.save_button":   "🔖 Save",
+	"bookmark.saved":         "🔖 Article saved, bookmarks: /saved in private messages with the bot",
+	"bookmark.already_saved": "The article is already bookmarked.",
+	"bookmark.removed":       "Bookmark removed.",
+	"bookmark.private_only":  "Bookmarks are available in private messages with the bot.",
+	"bookmark.header":        "🔖 Saved articles",
+	"bookmark.empty":         "You have no saved articles. Press «🔖 Save» under a channel post or in /search results.",
+	"bookmark.hint":          "❌ N - remove the bookmark, /saved export - export bookmarks to Markdown",
+	"bookmark.export_title":  "Saved articles",
+	"bookmark.usage":         "/saved - list saved articles\n/saved export - export saved articles as a Markdown file",
+
+	"usage.header":          "📊 Summarizer usage",
+	"usage.budget":          "Monthly budget: $%.2f of $%.2f (%.0f%%)",
+	"usage.budget_exceeded": "⚠️ Budget exceeded, summaries are built by fallback providers.",
+	"usage.no_budget":       "No monthly budget, spent $%.2f",
+	"usage.month":           "This month: %s",
+	"usage.total":           "%d requests, %d tokens (prompt %d, completion %d), $%.4f",
+	"usage.days":            "Last %d days:",
+	"usage.sources":         "By source this month:",
+	"usage.chats":           "By channel this month:",
+	"usage.empty":           "No summarizer requests this month.",
+
+	"article.read_more": "Read more",
+
+	"cmd.help":        "List of available commands",
+	"cmd.cancel":      "Cancel the current action",
+	"cmd.list":        "List sources",
+	"cmd.add":         "Add a source, without arguments the bot asks step by step",
+	"cmd.edit":        "Change the name or URL of a source",
+	"cmd.delete":      "Delete a source",
+	"cmd.grant":       "Grant a role to a user",
+	"cmd.revoke":      "Revoke a granted role from a user",
+	"cmd.lang":        "Bot language",
+	"cmd.sources":     "Sources you can subscribe to",
+	"cmd.subscribe":   "Subscribe to a source",
+	"cmd.unsubscribe": "Unsubscribe from a source",
+	"cmd.alert":       "Notifications about articles by keywords",
+	"cmd.search":      "Search articles",
+	"cmd.latest":      "Latest articles",
+	"cmd.source":      "Source health and its articles",
+	"cmd.saved":       "Saved articles",
+	"cmd.usage":       "Summarizer token usage and cost",
+
+	"args.source_id":       "Source ID",
+	"args.source_name":     "Source name, quote names with spaces",
+	"args.source_url":      "RSS feed URL of the source",
+	"args.source_new_name": `New source name, e.g. name="Go Blog"`,
+	"args.source_new_url":  "New RSS feed URL, e.g. url=https://go.dev/blog/feed.atom",
+	"args.role":            "Role: owner, editor or viewer",
+	"args.user":            "User ID, can be omitted when the command is sent as a reply to a message of the user",
+	"args.locale":          "Language: ru, en or auto",
+	"args.lang_target":     "channel - change the language of channel posts (administrators only)",
+	"args.alert_id":        "Alert ID",
+	"args.latest_count":    "How many articles to show per page, up to 30",
+	"args.usage_days":      "How many recent days to show, 7 by default",
+}
@@ -0,0 +1,91 @@
+package i18n
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+type Locale string // Язык сообщений бота
+
+const (
+	Russian Locale = "ru"
+	English Locale = "en"
+
+	Default = Russian // Язык который используется если язык пользователя не поддерживается
+)
+
+var catalogs = map[Locale]map[string]string{ // Каталоги сообщений, ключи сообщений объявлены в botkit/user_message.go
+	Russian: ru,
+	English: en,
+}
+
+func Locales() []Locale { // Функция возвращает список поддерживаемых языков
+	return []Locale{Russian, English}
+}
+
+func Parse(code string) (Locale, bool) { // Функция для получения языка из language_code телеграма, например "en-US"
+	code = strings.ToLower(strings.TrimSpace(code))
+	if i := strings.IndexAny(code, "-_"); i > 0 {
+		code = code[:i]
+	}
+
+	locale := Locale(code)
+	if _, ok := catalogs[locale]; !ok {
+		return Default, false
+	}
+
+	return locale, true
+}
+
+func T(locale Locale, key string, args ...any) string { // Функция возвращает сообщение на нужном языке, если перевода нет используется язык по умолчанию
+	msg, ok := catalogs[locale][key]
+	if !ok {
+		if msg, ok = catalogs[Default][key]; !ok { // Если ключа нет ни в одном каталоге возвращаем сам ключ, так проще заметить пропущенный перевод
+			msg = key
+		}
+	}
+
+	if len(args) > 0 {
+		return fmt.Sprintf(msg, args...)
+	}
+
+	return msg
+}
+
+type localeKey struct{}
+
+func WithLocale(ctx context.Context, locale Locale) context.Context { // Функция сохраняет язык пользователя в контекст
+	return context.WithValue(ctx, localeKey{}, locale)
+}
+
+func FromContext(ctx context.Context) Locale { // Функция достает язык пользователя из контекста
+	if locale, ok := ctx.Value(localeKey{}).(Locale); ok {
+		return locale
+	}
+
+	return Default
+}
+
+type Error struct { // Ошибка текст которой показывается пользователю на его языке
+	Key  string
+	Args []any
+}
+
+func NewError(key string, args ...any) error {
+	return &Error{Key: key, Args: args}
+}
+
+func (e *Error) Error() string {
+	return T(Default, e.Key, e.Args...)
+}
+
+func ErrorText(locale Locale, err error) string { // Функция возвращает текст ошибки на нужном языке
+	var localized *Error
+	if errors.As(err, &localized) {
+		return T(locale, localized.Key, localized.Args...)
+	}
+
+	return err.Error()
+}
@@ -0,0 +1,57 @@
+package i18n
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+)
+
+type ChatLocaleStorage interface { // Интерфейс для работы со слоем storage/chat_settings.go
+	ChatLocale(ctx context.Context, chatID int64) (string, error)
+}
+
+type Resolver struct { // Структура для выбора языка сообщений
+	storage  ChatLocaleStorage
+	fallback Locale // Язык если у чата нет своей настройки и язык пользователя не поддерживается
+}
+
+func NewResolver(storage ChatLocaleStorage, fallback Locale) *Resolver { // Конструктор для структуры Resolver
+	return &Resolver{
+		storage:  storage,
+		fallback: fallback,
+	}
+}
+
+func (r *Resolver) Resolve(ctx context.Context, chatID int64, languageCode string) Locale { // Метод выбирает язык: настройка чата (/lang), затем язык телеграма пользователя, затем язык по умолчанию
+	if locale, ok := r.chatLocale(ctx, chatID); ok {
+		return locale
+	}
+
+	if locale, ok := Parse(languageCode); ok {
+		return locale
+	}
+
+	return r.fallback
+}
+
+func (r *Resolver) ChatLocale(ctx context.Context, chatID int64) Locale { // Метод возвращает язык чата, например канала в который публикуются статьи
+	if locale, ok := r.chatLocale(ctx, chatID); ok {
+		return locale
+	}
+
+	return r.fallback
+}
+
+func (r *Resolver) chatLocale(ctx context.Context, chatID int64) (Locale, bool) {
+	code, err := r.storage.ChatLocale(ctx, chatID)
+	if err != nil { // Ошибка БД не должна мешать ответу пользователю
+		logrus.Errorf("failed to get locale of chat %d: %v", chatID, err)
+		return "", false
+	}
+
+	if code == "" {
+		return "", false
+	}
+
+	return Parse(code)
+}
@@ -0,0 +1,138 @@
+package i18n
+
+var ru = map[string]string{ // Сообщения на русском языке
+	"invalid_command":   "Неизветная команда.\nДоступные комманды: /help - Список команд",
+	"help.header":       "Доступные команды:",
+	"help.json_args":    `Аргументы команд также можно передавать JSON объектом, например /delete {"id":5}`,
+	"invalid_args":      "Некорректные аргументы команды. Использование:\n\n%s",
+	"access_denied":     "У вас нет прав для выполнения данной команды",
+	"too_many_requests": "Слишком много запросов, попробуйте немного позже.",
+	"not_a_command":     "Я принимаю только команды, /help для отоброжения списка команд\\.",
+	"internal_error":    "Внутренняя ошибка, попробуйте позже.",
+	"empty_answer":      "Ответ не может быть пустым.",
+
+	"conversation.cancel_hint":       "/cancel - отменить",
+	"conversation.canceled":          "Действие отменено.",
+	"conversation.nothing_to_cancel": "Нет активного действия для отмены.",
+
+	"source.ask_url":      "Отправьте ссылку на rss ленту источника.",
+	"source.ask_name":     "Теперь отправьте имя источника.",
+	"source.ask_id":       "Отправьте ID источника. Список источников можно посмотреть командой /list.",
+	"source.ask_new_name": `Отправьте новое имя источника или "-" чтобы оставить текущее.`,
+	"source.ask_new_url":  `Отправьте новую ссылку на rss ленту или "-" чтобы оставить текущую.`,
+	"source.invalid_url":  "Некорректная ссылка, ожидается адрес вида https://example.com/feed.xml",
+	"source.invalid_id":   "ID источника должен быть положительным числом.",
+	"source.not_found":    "Источник с таким ID не найден.",
+	"source.added":        "Источник добавлен с ID: `%d`\\. Используйте этот ID для управления источником\\.",
+	"source.edited":       "Источник с ID: `%d` изменен\\.\n\n%s",
+	"source.deleted":      "Источник удален с ID: `%d`\\.",
+	"source.list":         "Список источников\\(Всего %d\\):\n\n%s",
+	"source.info":         "🌎 *%s*\nID: `%d`\nURL feed: %s",
+
+	"roles.user_required":     "Укажите ID пользователя или ответьте командой на его сообщение.",
+	"roles.invalid_role":      "Неизвестная роль, доступные роли: owner, editor, viewer.",
+	"roles.cant_manage":       "Вы не можете выдавать или забирать эту роль.",
+	"roles.granted":           "Пользователю %d выдана роль %s.",
+	"roles.revoked":           "У пользователя %d больше нет выданной роли.",
+	"roles.nothing_to_revoke": "У пользователя %d нет выданной роли.",
+
+	"lang.current":         "Язык этого чата: %s. Доступные языки: %s.\n/lang en - сменить язык, /lang auto - использовать язык из настроек телеграма.",
+	"lang.changed":         "Язык чата изменен на %s.",
+	"lang.reset":           "Теперь бот использует язык из настроек телеграма.",
+	"lang.unknown":         "Неизвестный язык, доступные языки: %s.",
+	"lang.channel_changed": "Язык публикаций в канале изменен на %s.",
+
+	"subscription.private_only":       "Подписки доступны только в личных сообщениях с ботом.",
+	"subscription.no_sources":         "Пока нет ни одного источника.",
+	"subscription.sources":            "Источники (✅ - вы подписаны):\n\n%s\n\n/subscribe ID - подписаться, /unsubscribe ID - отписаться",
+	"subscription.subscribed":         "Вы подписались на источник %s, новые статьи будут приходить в этот чат.",
+	"subscription.already_subscribed": "Вы уже подписаны на источник %s.",
+	"subscription.unsubscribed":       "Вы отписались от источника %s.",
+	"subscription.not_subscribed":     "Вы не подписаны на источник %s.",
+
+	"alert.private_only": "Алерты настраиваются в личных сообщениях с ботом, туда же приходят уведомления.",
+	"alert.added":        "Алерт %d добавлен: %s\nКогда в новой статье найдется совпадение, я пришлю ее сюда.",
+	"alert.invalid":      "Не удалось разобрать выражение: %s\n\n%s",
+	"alert.too_long":     "Выражение слишком длинное, максимум %d символов.",
+	"alert.limit":        "У вас уже %d алертов, удалите ненужные командой /alert remove ID.",
+	"alert.list":         "Ваши алерты:\n\n%s\n\n/alert remove ID - удалить",
+	"alert.list_empty":   "У вас нет алертов. Добавьте первый: /alert add CVE openssl",
+	"alert.removed":      "Алерт %d удален.",
+	"alert.not_found":    "У вас нет алерта с ID %d.",
+	"alert.matched":      "🔔 Сработал алерт: %s\n\n%s\n%s",
+	"alert.usage":        "/alert add <выражение> - добавить алерт\n/alert list - список алертов\n/alert remove <ID> - удалить алерт\n\nСлова через пробел должны встретиться все, OR или | - любое из условий, -слово - слово не должно встречаться, \"фраза\" ищется целиком, скобки группируют условия.\nНапример: CVE (openssl | nginx) -windows",
+
+	"search.usage":         "/search <запрос> - поиск по статьям\n\nФильтры:\nsource:5, source:habr или source:\"Hacker News\" - только статьи источника (ID или часть названия)\nsince:24h, since:7d, since:2w или since:2025-01-31 - только статьи опубликованные после указанного времени\n\nНапример: /search go generics source:habr since:30d",
+	"search.no_results":    "Ничего не найдено.",
+	"search.invalid_since": "Не удалось разобрать фильтр since:%s. Примеры: since:24h, since:7d, since:2w, since:2025-01-31",
+	"search.expired":       "Результаты поиска устарели, повторите команду /search.",
+
+	"pagination.page": "Страница %d",
+	"pagination.prev": "◀️ Назад",
+	"pagination.next": "Дальше ▶️",
+
+	"latest.header":         "🆕 Последние статьи",
+	"latest.empty":          "Статей пока нет.",
+	"source.health_ok":      "✅ Загружается без ошибок, последняя загрузка %s",
+	"source.health_failing": "⚠️ Ошибок загрузки подряд: %d, последняя попытка %s\nОшибка: %s",
+	"source.health_unknown": "❔ Еще не загружался",
+	"source.stats":          "Статей: %d, опубликовано в канале: %d, самая свежая от %s",
+	"source.articles":       `Последние статьи \(✅ опубликована, 🕓 ждет публикации\):`,
+	"source.no_articles":    `Статей из этого источника пока нет\.`,
+
+	"bookmark.save_button":   "🔖 Сохранить",
+	"bookmark.saved":         "🔖 Статья сохранена, закладки: /saved в личных сообщениях с ботом",
+	"bookmark.already_saved": "Статья уже в закладках.",
+	"bookmark.removed":       "Закладка удалена.",
+	"bookmark.private_only":  "Закладки доступны в личных сообщениях с ботом.",
+	"bookmark.header":        "🔖 Сохраненные статьи",
+	"bookmark.empty":         "У вас нет сохраненных статей. Нажмите «🔖 Сохранить» под публикацией в канале или в результатах /search.",
+	"bookmark.hint":          "❌ N - удалить закладку, /saved export - выгрузить закладки в Markdown",
+	"bookmark.export_title":  "Сохраненные статьи",
+	"bookmark.usage":         "/saved - список сохраненных статей\n/saved export - выгрузить сохраненные статьи файлом Markdown",
+
+	"usage.header":          "📊 Расход на саммари",
+	"usage.budget":          "Бюджет на месяц: $%.2f из $%.2f (%.0f%%)",
+	"usage.budget_exceeded": "⚠️ Бюджет исчерпан, саммари строят запасные провайдеры.",
+	"usage.no_budget":       "Бюджет на месяц не задан, потрачено $%.2f",
+	"usage.month":           "За месяц: %s",
+	"usage.total":           "запросов %d, токенов %d (запрос %d, ответ %d), $%.4f",
+	"usage.days":            "За последние %d дн.:",
+	"usage.sources":         "По источникам за месяц:",
+	"usage.chats":           "По каналам за месяц:",
+	"usage.empty":           "В этом месяце запросов к модели не было.",
+
+	"article.read_more": "Читать полностью",
+
+	"cmd.help":        "Список доступных команд",
+	"cmd.cancel":      "Отменить текущее действие",
+	"cmd.list":        "Список источников",
+	"cmd.add":         "Добавить источник, без аргументов бот спросит данные по шагам",
+	"cmd.edit":        "Изменить имя или ссылку источника",
+	"cmd.delete":      "Удалить источник",
+	"cmd.grant":       "Выдать пользователю роль",
+	"cmd.revoke":      "Забрать у пользователя выданную роль",
+	"cmd.lang":        "Язык сообщений бота",
+	"cmd.sources":     "Источники для подписки",
+	"cmd.subscribe":   "Подписаться на источник",
+	"cmd.unsubscribe": "Отписаться от источника",
+	"cmd.alert":       "Уведомления о статьях по ключевым словам",
+	"cmd.search":      "Поиск по статьям",
+	"cmd.latest":      "Последние статьи",
+	"cmd.source":      "Состояние источника и его статьи",
+	"cmd.saved":       "Сохраненные статьи",
+	"cmd.usage":       "Расход токенов и стоимость саммари",
+
+	"args.source_id":       "ID источника",
+	"args.source_name":     "Имя источника, имя с пробелами берется в кавычки",
+	"args.source_url":      "Ссылка на rss ленту источника",
+	"args.source_new_name": `Новое имя источника, например name="Go Blog"`,
+	"args.source_new_url":  "Новая ссылка на rss ленту, например url=https://go.dev/blog/feed.atom",
+	"args.role":            "Роль: owner, editor или viewer",
+	"args.user":            "ID пользователя, можно не указывать если команда отправлена ответом на сообщение пользователя",
+	"args.locale":          "Язык: ru, en или auto",
+	"args.lang_target":     "channel - изменить язык публикаций в канале (только для администраторов)",
+	"args.alert_id":        "ID алерта",
+	"args.latest_count":    "Сколько статей показать на странице, до 30",
+	"args.usage_days":      "Сколько последних дней показать, по умолчанию 7",
+}
@@ -0,0 +1,10 @@
+package models
+
+import "time"
+
+type Alert struct { // Структура Alert для уведомлений пользователя по ключевым словам
+	ID         int64
+	UserID     int64
+	Expression string // Выражение в синтаксисе пакета alert, например: CVE (nginx | openssl)
+	Created    time.Time
+}
@@ -3,12 +3,15 @@ package models
 import "time"
 
 type Article struct { // Стркутура Article для статей
-	ID        int64
-	SourceID  int64
-	Title     string
-	Link      string
-	Summary   string
-	Published time.Time
-	Posted    time.Time
-	Created   time.Time
+	ID         int64
+	SourceID   int64
+	SourceName string // Название источника, заполняется только запросами для публикации
+	Title      string
+	Link       string
+	Summary    string
+	Published  time.Time
+	Posted     time.Time
+	Created    time.Time
+
+	GeneratedSummary GeneratedSummary // Сохраненное саммари, пустое если статья еще не обрабатывалась провайдером
 }
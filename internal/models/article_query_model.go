@@ -0,0 +1,12 @@
+package models
+
+import "time"
+
+type ArticleQuery struct { // Параметры поиска статей
+	Text       string    // Поисковый запрос, если пустой статьи отбираются только по фильтрам
+	SourceID   int64     // Фильтр по ID источника, 0 если не задан
+	SourceName string    // Фильтр по части названия источника, пустой если не задан
+	Since      time.Time // Статьи опубликованные не раньше этого времени, нулевое значение если не задан
+	Limit      uint64
+	Offset     uint64
+}
@@ -0,0 +1,12 @@
+package models
+
+import "time"
+
+type GeneratedSummary struct { // Саммари статьи построенное провайдером, сохраняется чтобы не запрашивать его повторно
+	Text       string
+	Title      string    // Заголовок переведенный на язык канала, пустой если перевод не нужен
+	Provider   string    // Имя провайдера который построил саммари
+	Model      string    // Модель провайдера, пустая у провайдеров без модели
+	PromptHash string    // Хэш запроса к модели, при смене запроса саммари строится заново
+	Created    time.Time // Время построения, нулевое если саммари еще нет
+}
@@ -0,0 +1,40 @@
+package models
+
+import "time"
+
+type Role string // Роль пользователя в управлении ботом
+
+const (
+	RoleNone   Role = ""       // Пользователь без прав
+	RoleViewer Role = "viewer" // Может просматривать источники
+	RoleEditor Role = "editor" // Может управлять источниками
+	RoleAdmin  Role = "admin"  // Администратор канала, роль не хранится в БД и берется из телеграма
+	RoleOwner  Role = "owner"  // Владелец канала, может выдавать любые роли
+)
+
+var roleLevels = map[Role]int{ // Уровни ролей, роль с большим уровнем включает права ролей ниже
+	RoleNone:   0,
+	RoleViewer: 1,
+	RoleEditor: 2,
+	RoleAdmin:  3,
+	RoleOwner:  4,
+}
+
+func (r Role) Level() int { // Метод возвращает уровень роли, неизвестные роли не дают прав
+	return roleLevels[r]
+}
+
+func (r Role) AtLeast(other Role) bool { // Метод проверяет что роль включает права роли other
+	return r.Level() >= other.Level()
+}
+
+func (r Role) Grantable() bool { // Метод проверяет можно ли выдать роль командой (роль admin выдается только в телеграме)
+	return r == RoleOwner || r == RoleEditor || r == RoleViewer
+}
+
+type UserRole struct { // Структура UserRole для ролей выданных пользователям
+	UserID    int64
+	Role      Role
+	GrantedBy int64
+	Created   time.Time
+}
@@ -3,8 +3,17 @@ package models
 import "time"
 
 type Source struct { // Стркутура Source для источников
-	ID      int64
-	Name    string
-	FeedURL string
-	Created time.Time
+	ID          int64
+	Name        string
+	FeedURL     string
+	Created     time.Time
+	LastFetched time.Time // Время последней попытки загрузить ленту, нулевое если источник еще не загружался
+	LastError   string    // Ошибка последней загрузки, пустая если загрузка прошла успешно
+	ErrorCount  int       // Сколько загрузок подряд закончились ошибкой
+}
+
+type SourceStats struct { // Статистика статей источника
+	Articles      int       // Всего сохранено статей
+	Posted        int       // Сколько из них опубликовано в канал
+	LastPublished time.Time // Дата публикации самой свежей статьи
 }
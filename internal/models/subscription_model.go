@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+type Subscription struct { // Структура Subscription для подписок пользователей на источники
+	UserID   int64
+	SourceID int64
+	Created  time.Time
+}
+
+type Delivery struct { // Статья которую нужно отправить подписчику в личные сообщения
+	UserID  int64
+	Article Article
+}
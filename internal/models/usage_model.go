@@ -0,0 +1,22 @@
+package models
+
+import "time"
+
+type SummaryUsage struct { // Расход токенов одним запросом к модели
+	SourceID         int64  // Источник статьи, 0 если неизвестен
+	ChatID           int64  // Канал для которого строилось саммари
+	Provider         string // Провайдер саммари, например openai
+	Model            string
+	PromptTokens     int
+	CompletionTokens int
+	Cost             float64 // Стоимость запроса в долларах по ценам из конфига
+	Created          time.Time
+}
+
+type UsageTotal struct { // Суммарный расход токенов за период по дню, источнику или каналу
+	Key              string // День, название источника или ID канала
+	Requests         int
+	PromptTokens     int64
+	CompletionTokens int64
+	Cost             float64
+}
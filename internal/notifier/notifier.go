@@ -2,6 +2,7 @@ package notifier
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -13,39 +14,71 @@ import (
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 	"github.com/sirupsen/logrus"
 
+	"github.com/speeddem0n/GoNewsBot/internal/botkit"
 	"github.com/speeddem0n/GoNewsBot/internal/botkit/markup"
+	"github.com/speeddem0n/GoNewsBot/internal/i18n"
 	"github.com/speeddem0n/GoNewsBot/internal/models"
+	"github.com/speeddem0n/GoNewsBot/internal/summary"
 )
 
 type ArticleProvider interface { // Интейвейс для работы со стоем storage/article.go
 	AllNotPosted(ctx context.Context, since time.Time, limit uint64) ([]models.Article, error) // Метод для получения всех неопубликованных статей
 	MarkPosted(ctx context.Context, id int64) error                                            // Метод для отметки статьи как опубликованная
+	AllNotSummarized(ctx context.Context, since time.Time, provider string, limit uint64) ([]models.Article, error)
+	SaveGeneratedSummary(ctx context.Context, id int64, summary models.GeneratedSummary) error
+	ClaimSummary(ctx context.Context, id int64, summarized, until time.Time) (bool, error) // Метод занимает статью, чтобы публикация и воркер предварительных саммари не запрашивали провайдера одновременно
+	ReleaseSummary(ctx context.Context, id int64) error
 }
 
+const summaryClaimTTL = 10 * time.Minute // Сколько статья остается занятой, если воркер упал не сняв отметку
+
+var errSummaryInProgress = errors.New("summary is being generated by another worker")
+
 type Summarizer interface { // Интерфейс для связи со слоем openAPI
 	Summarize(ctx context.Context, text string) (string, error)
 }
 
+type SummaryGenerator interface { // Саммаризатор который сообщает каким провайдером, моделью и запросом построено саммари, например summary.Chain
+	Generate(ctx context.Context, text string) (models.GeneratedSummary, error)
+	PromptHash(ctx context.Context) string
+	PrimaryProvider() string
+}
+
+type SubscriptionProvider interface { // Интерфейс для работы со слоем storage/subscription.go
+	AllNotDelivered(ctx context.Context, since time.Time, limit uint64) ([]models.Delivery, error) // Метод для получения статей которые еще не отправлены подписчикам
+	MarkDelivered(ctx context.Context, userID, articleID int64) error                              // Метод для отметки статьи как отправленной подписчику
+}
+
+type ChatLocaleResolver interface { // Интерфейс для получения языка канала
+	ChatLocale(ctx context.Context, chatID int64) i18n.Locale
+}
+
 type Notifier struct { // Структура notifier
-	articles         ArticleProvider  // Интервейс для связи со слоем storage
-	summarizer       Summarizer       // Интерфейс для связи со слоем openAPI
-	bot              *tgbotapi.BotAPI // API tg бота
-	sendInterval     time.Duration    // Интервал с которым бот публикует сообщения в канал
-	lookupTimeWindow time.Duration    // Ограничение про времени публикации статьи которую бот будет постить
-	channelID        int64            // Id канала
+	articles         ArticleProvider      // Интервейс для связи со слоем storage
+	subscriptions    SubscriptionProvider // Подписки пользователей, статьи отправляются им в личные сообщения
+	summarizer       Summarizer           // Интерфейс для связи со слоем openAPI
+	bot              botkit.Messenger     // Клиент tg бота
+	locales          ChatLocaleResolver   // Язык канала для подписи под статьей
+	sendInterval     time.Duration        // Интервал с которым бот публикует сообщения в канал
+	lookupTimeWindow time.Duration        // Ограничение про времени публикации статьи которую бот будет постить
+	channelID        int64                // Id канала
 }
 
 func NewNotifier(articleProvider ArticleProvider,
+	subscriptions SubscriptionProvider,
 	summarizer Summarizer,
-	bot *tgbotapi.BotAPI,
+	bot botkit.Messenger,
+	locales ChatLocaleResolver,
 	sendInterval time.Duration,
 	lookupTimeWindow time.Duration,
 	channelID int64,
 ) *Notifier { // Конструктор для структуры Notifier
 	return &Notifier{
 		articles:         articleProvider,
+		subscriptions:    subscriptions,
 		summarizer:       summarizer,
 		bot:              bot,
+		locales:          locales,
 		sendInterval:     sendInterval,
 		lookupTimeWindow: lookupTimeWindow,
 		channelID:        channelID,
@@ -59,6 +92,7 @@ func (n *Notifier) Start(ctx context.Context) error { // Метод для за
 	if err := n.SelectAndSendArticle(ctx); err != nil { // Первый SelectAndSendArticle запуск без ожидания интервала
 		return err
 	}
+	n.sendSubscriptionsLogged(ctx)
 
 	for { // Бесконечный цикл
 		select {
@@ -66,6 +100,7 @@ func (n *Notifier) Start(ctx context.Context) error { // Метод для за
 			if err := n.SelectAndSendArticle(ctx); err != nil { // Вызываем SelectAndSendArticle
 				return err
 			}
+			n.sendSubscriptionsLogged(ctx)
 		case <-ctx.Done(): // Контекст завершен
 			return ctx.Err() // Возвращаем ошибку контекста
 		}
@@ -87,12 +122,16 @@ func (n *Notifier) SelectAndSendArticle(ctx context.Context) error { // Мето
 	article := topeOneArticles[0] // Берем первую статью в переменную article
 
 	summary, err := n.extractSummary(ctx, article) // получаем Summary статьи методом extractSummary
+	if errors.Is(err, errSummaryInProgress) {
+		logrus.Infof("summary of article %d is in progress, postponing", article.ID) // Саммари строит воркер предварительных саммари, статья будет опубликована на следующем тике уже с ним
+		return nil
+	}
 	if err != nil {
 		logrus.Errorf("Error on extract summary: %s", err)
 		return err
 	}
 
-	if err := n.sendArticle(article, summary); err != nil { // методом sendArticle публикуем статью в тг канал
+	if err := n.sendArticle(ctx, n.channelID, article, summary); err != nil { // методом sendArticle публикуем статью в тг канал
 		logrus.Errorf("Error on send article: %s", err)
 		return err
 	}
@@ -100,7 +139,34 @@ func (n *Notifier) SelectAndSendArticle(ctx context.Context) error { // Мето
 	return n.articles.MarkPosted(ctx, article.ID) // в конце вызываем метод MarkPosted и помечаем статью как опубликованную и возвращаем ошибку
 }
 
-func (n *Notifier) extractSummary(ctx context.Context, article models.Article) (string, error) { // Метод для получения Summary статьи
+func (n *Notifier) extractSummary(ctx context.Context, article models.Article) (models.GeneratedSummary, error) { // Метод для получения Summary статьи, сохраненное саммари используется повторно
+	if n.summaryCurrent(ctx, article) {
+		return article.GeneratedSummary, nil
+	}
+
+	return n.generateSummary(ctx, article) // Саммари еще нет, оно построено по старому запросу или запасным провайдером, а основной снова доступен
+}
+
+func (n *Notifier) summaryCurrent(ctx context.Context, article models.Article) bool { // Метод проверяет что сохраненное саммари построено тем провайдером и запросом, которые построили бы его сейчас
+	return !article.GeneratedSummary.Created.IsZero() && article.GeneratedSummary.PromptHash == n.promptHash(n.summaryContext(ctx, article))
+}
+
+func (n *Notifier) generateSummary(ctx context.Context, article models.Article) (models.GeneratedSummary, error) { // Метод строит саммари статьи и сохраняет его в бд, статья занимается на время запроса к провайдеру
+	ctx = n.summaryContext(ctx, article)
+
+	claimed, err := n.articles.ClaimSummary(ctx, article.ID, article.GeneratedSummary.Created, time.Now().Add(summaryClaimTTL))
+	if err != nil {
+		return models.GeneratedSummary{}, err
+	}
+	if !claimed {
+		return models.GeneratedSummary{}, errSummaryInProgress
+	}
+	defer func() {
+		if err := n.articles.ReleaseSummary(context.WithoutCancel(ctx), article.ID); err != nil { // Отметка снимается и при остановке сервиса, иначе статья будет занята до конца summaryClaimTTL
+			logrus.Errorf("failed to release summary claim of article %d: %v", article.ID, err)
+		}
+	}()
+
 	var r io.Reader // Создаем новый объект io.Reader
 
 	if article.Summary != "" { // Если у статьи есть Summary
@@ -109,7 +175,7 @@ func (n *Notifier) extractSummary(ctx context.Context, article models.Article) (
 		resp, err := http.Get(article.Link) // Если у статьи нет Summary и переходем по ее адресу и забираем http body
 		if err != nil {
 			logrus.Errorf("Error %s on request on %s", err, article.Link)
-			return "", err
+			return models.GeneratedSummary{}, err
 		}
 		defer resp.Body.Close() // Откладываем закрытия тела ответа
 
@@ -119,16 +185,64 @@ func (n *Notifier) extractSummary(ctx context.Context, article models.Article) (
 	doc, err := readability.FromReader(r, nil) // Форматируем с помошью библеотеки readability html разметку страницы в читаймый документ
 	if err != nil {
 		logrus.Errorf("Failed to parse an `io.Reader`: %s", err)
-		return "", nil
+		return models.GeneratedSummary{}, nil
 	}
 
-	summary, err := n.summarizer.Summarize(ctx, cleanText(doc.TextContent)) // Получаем summary методом Summarize
+	summary, err := n.summarize(ctx, cleanText(doc.TextContent)) // Получаем summary методом Summarize
 	if err != nil {
 		logrus.Errorf("Failed to get summary from summarizer.Summarize: %s", err)
-		return "", err
+		return models.GeneratedSummary{}, err
 	}
 
-	return "\n\n" + summary, nil // Две пустые строки для отступа после заголовка
+	if summary.Text == "" { // Пустое саммари не сохраняется, в следующий раз провайдеры могут ответить
+		return summary, nil
+	}
+
+	if err := n.articles.SaveGeneratedSummary(ctx, article.ID, summary); err != nil { // Саммари уже получено, ошибка сохранения не мешает публикации
+		logrus.Errorf("failed to save summary of article %d: %v", article.ID, err)
+	}
+
+	return summary, nil
+}
+
+func (n *Notifier) summarize(ctx context.Context, text string) (models.GeneratedSummary, error) { // Метод вызывает саммаризатор, провайдер и модель известны только у SummaryGenerator
+	if generator, ok := n.summarizer.(SummaryGenerator); ok {
+		return generator.Generate(ctx, text)
+	}
+
+	summary, err := n.summarizer.Summarize(ctx, text)
+	if err != nil {
+		return models.GeneratedSummary{}, err
+	}
+
+	return models.GeneratedSummary{Text: summary, Created: time.Now()}, nil
+}
+
+func (n *Notifier) promptHash(ctx context.Context) string { // Метод возвращает хэш текущего запроса к модели для статьи из контекста
+	if generator, ok := n.summarizer.(SummaryGenerator); ok {
+		return generator.PromptHash(ctx)
+	}
+
+	return ""
+}
+
+func (n *Notifier) primaryProvider() string { // Метод возвращает основной провайдер саммари, пустая строка если провайдер не сообщает своего имени
+	if generator, ok := n.summarizer.(SummaryGenerator); ok {
+		return generator.PrimaryProvider()
+	}
+
+	return ""
+}
+
+func (n *Notifier) summaryContext(ctx context.Context, article models.Article) context.Context { // Метод добавляет в контекст данные статьи для шаблона запроса к модели
+	// Саммари сохраняется одно на статью, поэтому язык и шаблон выбираются по каналу и для подписчиков тоже
+	return summary.WithPromptData(ctx, summary.PromptData{
+		Title:    article.Title,
+		Source:   article.SourceName,
+		SourceID: article.SourceID,
+		ChatID:   n.channelID,
+		Language: string(n.locales.ChatLocale(ctx, n.channelID)), // Используется если включен перевод на язык канала
+	})
 }
 
 var redundantNewLines = regexp.MustCompile(`\n{3,}`) // Регулярка соответствует всем последовательностям пустых строк, где они идут 3 и более раз подряд
@@ -137,19 +251,34 @@ func cleanText(text string) string { // Функция для очистки т
 	return redundantNewLines.ReplaceAllString(text, "\n")
 }
 
-func (n *Notifier) sendArticle(article models.Article, summary string) error { // Метод для публикации статьи в чат
-	const msgFormat = "*%s*%s\n\n%s" // Шаблон сообщения
+func (n *Notifier) sendArticle(ctx context.Context, chatID int64, article models.Article, summary models.GeneratedSummary) error { // Метод для публикации статьи в канал или личные сообщения подписчика
+	const msgFormat = "%s%s\n\n[%s](%s)" // Шаблон сообщения
+
+	locale := n.locales.ChatLocale(ctx, chatID) // Подпись под статьей на языке чата
+
+	title := "*" + markup.EscapeForMarkdown(article.Title) + "*" // Вызывается EscapeForMarkdown для замены Markdown спец символов
+
+	if summary.Title != "" { // Переведенный заголовок идет первым, исходный остается под ним
+		title = "*" + markup.EscapeForMarkdown(summary.Title) + "*\n_" + markup.EscapeForMarkdown(article.Title) + "_"
+	}
+
+	text := ""
+	if summary.Text != "" { // Провайдеры саммари не ответили, статья публикуется только с заголовком
+		text = "\n\n" + markup.EscapeForMarkdown(summary.Text) // Две пустые строки для отступа после заголовка
+	}
 
-	msg := tgbotapi.NewMessage(n.channelID, fmt.Sprintf(
+	msg := tgbotapi.NewMessage(chatID, fmt.Sprintf(
 		msgFormat,
-		markup.EscapeForMarkdown(article.Title), // Вызывается EscapeForMarkdown для замены Markdown спец символов
-		markup.EscapeForMarkdown(summary),
-		markup.EscapeForMarkdown(article.Link),
+		title,
+		text,
+		markup.EscapeForMarkdown(i18n.T(locale, botkit.ReadMoreMsg)),
+		markup.EscapeLinkURL(article.Link),
 	)) // Создаем новое сообщение для бота
 
-	msg.ParseMode = tgbotapi.ModeMarkdownV2 // Сообщение парсится как MarkdownV2 сообщение
+	msg.ParseMode = tgbotapi.ModeMarkdownV2                          // Сообщение парсится как MarkdownV2 сообщение
+	msg.ReplyMarkup = botkit.SaveArticleKeyboard(locale, article.ID) // Кнопка "Сохранить" добавляет статью в закладки нажавшего пользователя
 
-	_, err := n.bot.Send(msg) // Send will send a Chattable item to Telegram.
+	_, err := n.bot.Send(msg) // Отправляем сообщение в канал
 	if err != nil {
 		logrus.Errorf("Faildes to send msg to telegram: %s", err)
 		return err
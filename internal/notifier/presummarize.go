@@ -0,0 +1,59 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+func (n *Notifier) StartPresummarizer(ctx context.Context, interval time.Duration, batch uint64) error { // Метод заранее строит саммари статей из очереди публикации, чтобы публикация не ждала провайдера
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := n.Presummarize(ctx, batch); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			logrus.Errorf("failed to presummarize articles: %v", err) // Ошибка бд не останавливает воркер, публикация построит саммари сама
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (n *Notifier) Presummarize(ctx context.Context, batch uint64) error { // Метод строит и сохраняет саммари batch следующих статей очереди
+	articles, err := n.articles.AllNotSummarized(ctx, time.Now().Add(-n.lookupTimeWindow), n.primaryProvider(), batch) // Саммари запасного провайдера тоже перестраиваются, чтобы публикация не ждала основной провайдер
+	if err != nil {
+		return err
+	}
+
+	var wg sync.WaitGroup
+
+	for _, article := range articles { // Статьи обрабатываются параллельно, количество одновременных запросов ограничивает провайдер саммари
+		if n.summaryCurrent(ctx, article) { // Основной провайдер все еще пропускается, саммари запасного остается
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			if _, err := n.generateSummary(ctx, article); err != nil && ctx.Err() == nil && !errors.Is(err, errSummaryInProgress) { // Статью уже обрабатывает публикация
+				// Статья будет обработана снова на следующем тике или при публикации
+				logrus.Errorf("failed to presummarize article %d: %v", article.ID, err)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	return ctx.Err()
+}
@@ -0,0 +1,277 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/speeddem0n/GoNewsBot/internal/botkit/botkittest"
+	"github.com/speeddem0n/GoNewsBot/internal/i18n"
+	"github.com/speeddem0n/GoNewsBot/internal/models"
+	"github.com/speeddem0n/GoNewsBot/internal/summary"
+)
+
+const testChannelID = -100500
+
+type memoryArticles struct { // Хранилище статей в памяти вместо storage.ArticlePostgresStorage
+	mu       sync.Mutex
+	articles map[int64]*models.Article
+	claimed  map[int64]time.Time
+}
+
+func newMemoryArticles(articles ...models.Article) *memoryArticles {
+	s := &memoryArticles{articles: make(map[int64]*models.Article), claimed: make(map[int64]time.Time)}
+	for _, article := range articles {
+		s.articles[article.ID] = &article
+	}
+
+	return s
+}
+
+func (s *memoryArticles) get(id int64) models.Article {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return *s.articles[id]
+}
+
+func (s *memoryArticles) AllNotPosted(ctx context.Context, since time.Time, limit uint64) ([]models.Article, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var articles []models.Article
+	for _, article := range s.articles {
+		if article.Posted.IsZero() {
+			articles = append(articles, *article)
+		}
+	}
+
+	return articles, nil
+}
+
+func (s *memoryArticles) AllNotSummarized(ctx context.Context, since time.Time, provider string, limit uint64) ([]models.Article, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var articles []models.Article
+	for _, article := range s.articles {
+		summarized := !article.GeneratedSummary.Created.IsZero() && (provider == "" || article.GeneratedSummary.Provider == provider)
+		if article.Posted.IsZero() && !summarized && s.claimed[article.ID].Before(time.Now()) {
+			articles = append(articles, *article)
+		}
+	}
+
+	return articles, nil
+}
+
+func (s *memoryArticles) MarkPosted(ctx context.Context, id int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.articles[id].Posted = time.Now()
+
+	return nil
+}
+
+func (s *memoryArticles) SaveGeneratedSummary(ctx context.Context, id int64, summary models.GeneratedSummary) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.articles[id].GeneratedSummary = summary
+
+	return nil
+}
+
+func (s *memoryArticles) ClaimSummary(ctx context.Context, id int64, summarized, until time.Time) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.claimed[id].After(time.Now()) || !s.articles[id].GeneratedSummary.Created.Equal(summarized) {
+		return false, nil
+	}
+
+	s.claimed[id] = until
+
+	return true, nil
+}
+
+func (s *memoryArticles) ReleaseSummary(ctx context.Context, id int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.claimed, id)
+
+	return nil
+}
+
+type blockingSummarizer struct { // Саммаризатор который отвечает только после закрытия release
+	mu      sync.Mutex
+	calls   int
+	started chan struct{}
+	release chan struct{}
+}
+
+func (s *blockingSummarizer) Summarize(ctx context.Context, text string) (string, error) {
+	s.mu.Lock()
+	s.calls++
+	s.mu.Unlock()
+
+	s.started <- struct{}{}
+	<-s.release
+
+	return "Go 1.24 вышел.", nil
+}
+
+func (s *blockingSummarizer) Calls() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.calls
+}
+
+type stubSummarizer struct { // Саммаризатор с заданным ответом
+	mu    sync.Mutex
+	reply string
+	err   error
+	calls int
+}
+
+func (s *stubSummarizer) Summarize(ctx context.Context, text string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.calls++
+
+	return s.reply, s.err
+}
+
+func (s *stubSummarizer) Calls() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.calls
+}
+
+type fixedLocale i18n.Locale
+
+func (l fixedLocale) ChatLocale(ctx context.Context, chatID int64) i18n.Locale { return i18n.Locale(l) }
+
+func TestPresummarizeAndPublishDoNotSummarizeTwice(t *testing.T) {
+	var (
+		articles   = newMemoryArticles(models.Article{ID: 1, Title: "Go 1.24", Link: "https://go.dev/blog/go1.24", Summary: "Go 1.24 is released with generic type aliases."})
+		summarizer = &blockingSummarizer{started: make(chan struct{}, 1), release: make(chan struct{})}
+		messenger  = botkittest.NewFakeMessenger()
+		n          = NewNotifier(articles, nil, summarizer, messenger, fixedLocale(i18n.Russian), time.Minute, time.Hour, testChannelID)
+		ctx        = context.Background()
+	)
+
+	done := make(chan error, 1)
+	go func() { done <- n.Presummarize(ctx, 5) }()
+
+	<-summarizer.started // Воркер занял статью и ждет ответа провайдера
+
+	if err := n.SelectAndSendArticle(ctx); err != nil {
+		t.Fatalf("SelectAndSendArticle() error = %v", err)
+	}
+	if len(messenger.Sent()) != 0 || !articles.get(1).Posted.IsZero() {
+		t.Fatal("article was published while its summary was in progress")
+	}
+
+	close(summarizer.release)
+	if err := <-done; err != nil {
+		t.Fatalf("Presummarize() error = %v", err)
+	}
+
+	if err := n.SelectAndSendArticle(ctx); err != nil {
+		t.Fatalf("SelectAndSendArticle() error = %v", err)
+	}
+
+	if calls := summarizer.Calls(); calls != 1 {
+		t.Errorf("summarizer called %d times, want 1", calls)
+	}
+	if sent := messenger.Sent(); len(sent) != 1 || articles.get(1).Posted.IsZero() {
+		t.Errorf("article is not published after presummarize, sent %d messages", len(sent))
+	}
+}
+
+func TestClaimSkipsStaleSnapshot(t *testing.T) {
+	var (
+		article    = models.Article{ID: 1, Title: "Go 1.24", Link: "https://go.dev/blog/go1.24", Summary: "Go 1.24 is released with generic type aliases."}
+		articles   = newMemoryArticles(article)
+		summarizer = &blockingSummarizer{started: make(chan struct{}, 1), release: make(chan struct{})}
+		n          = NewNotifier(articles, nil, summarizer, botkittest.NewFakeMessenger(), fixedLocale(i18n.Russian), time.Minute, time.Hour, testChannelID)
+	)
+
+	articles.SaveGeneratedSummary(context.Background(), 1, models.GeneratedSummary{Text: "Готово.", Created: time.Now()}) // Саммари построено после того как статья была прочитана
+
+	if _, err := n.generateSummary(context.Background(), article); !errors.Is(err, errSummaryInProgress) {
+		t.Errorf("generateSummary() error = %v, want errSummaryInProgress", err)
+	}
+	if calls := summarizer.Calls(); calls != 0 {
+		t.Errorf("summarizer called %d times for already summarized article", calls)
+	}
+}
+
+func newFallbackChain(primary, fallback summary.Summarizer) *summary.Chain {
+	return summary.NewChain(summary.ChainConfig{FailureThreshold: 1, Cooldown: time.Hour},
+		summary.ChainProvider{Name: "openai", Summarizer: primary},
+		summary.ChainProvider{Name: "textrank", Summarizer: fallback},
+	)
+}
+
+func TestFallbackSummaryIsKeptWhilePrimaryIsSkipped(t *testing.T) {
+	var (
+		articles  = newMemoryArticles(models.Article{ID: 1, Title: "Go 1.24", Link: "https://go.dev/blog/go1.24", Summary: "Go 1.24 is released with generic type aliases."})
+		primary   = &stubSummarizer{err: errors.New("unavailable")}
+		fallback  = &stubSummarizer{reply: "Go 1.24 вышел."}
+		messenger = botkittest.NewFakeMessenger()
+		n         = NewNotifier(articles, nil, newFallbackChain(primary, fallback), messenger, fixedLocale(i18n.Russian), time.Minute, time.Hour, testChannelID)
+		ctx       = context.Background()
+	)
+
+	for range 2 { // Второй проход не должен снова строить саммари, пока предохранитель основного провайдера открыт
+		if err := n.Presummarize(ctx, 5); err != nil {
+			t.Fatalf("Presummarize() error = %v", err)
+		}
+	}
+	if err := n.SelectAndSendArticle(ctx); err != nil {
+		t.Fatalf("SelectAndSendArticle() error = %v", err)
+	}
+
+	if calls := primary.Calls(); calls != 1 {
+		t.Errorf("primary called %d times, want 1", calls)
+	}
+	if calls := fallback.Calls(); calls != 1 {
+		t.Errorf("fallback called %d times, want 1", calls)
+	}
+	if provider := articles.get(1).GeneratedSummary.Provider; provider != "textrank" {
+		t.Errorf("summary provider = %q, want textrank", provider)
+	}
+	if len(messenger.Sent()) != 1 {
+		t.Errorf("sent %d messages, want 1", len(messenger.Sent()))
+	}
+}
+
+func TestPresummarizeRebuildsFallbackSummary(t *testing.T) {
+	var (
+		articles = newMemoryArticles(models.Article{ID: 1, Title: "Go 1.24", Link: "https://go.dev/blog/go1.24", Summary: "Go 1.24 is released with generic type aliases.",
+			GeneratedSummary: models.GeneratedSummary{Text: "Go 1.24 вышел.", Provider: "textrank", PromptHash: "fallback", Created: time.Now().Add(-time.Hour)},
+		})
+		primary  = &stubSummarizer{reply: "Вышел Go 1.24 с псевдонимами типов."}
+		fallback = &stubSummarizer{reply: "Go 1.24 вышел."}
+		n        = NewNotifier(articles, nil, newFallbackChain(primary, fallback), botkittest.NewFakeMessenger(), fixedLocale(i18n.Russian), time.Minute, time.Hour, testChannelID)
+	)
+
+	if err := n.Presummarize(context.Background(), 5); err != nil { // Основной провайдер снова отвечает, саммари запасного перестраивается до публикации
+		t.Fatalf("Presummarize() error = %v", err)
+	}
+
+	if calls := primary.Calls(); calls != 1 {
+		t.Errorf("primary called %d times, want 1", calls)
+	}
+	if summary := articles.get(1).GeneratedSummary; summary.Provider != "openai" || summary.Text != primary.reply {
+		t.Errorf("summary = %+v, want summary of primary provider", summary)
+	}
+}
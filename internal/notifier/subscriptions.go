@@ -0,0 +1,63 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/speeddem0n/GoNewsBot/internal/models"
+)
+
+const subscriptionBatchSize = 50 // Сколько статей отправляется подписчикам за один тик
+
+func (n *Notifier) SendSubscriptions(ctx context.Context) error { // Метод для отправки новых статей подписчикам в личные сообщения
+	deliveries, err := n.subscriptions.AllNotDelivered(ctx, time.Now().Add(-n.lookupTimeWindow), subscriptionBatchSize)
+	if err != nil {
+		return err
+	}
+
+	var (
+		summaries  = make(map[int64]models.GeneratedSummary) // Одна статья может уйти нескольким подписчикам, summary получаем один раз
+		inProgress = make(map[int64]bool)                    // Статьи, саммари которых сейчас строит другой воркер, отправляются на следующем тике
+	)
+
+	for _, delivery := range deliveries {
+		article := delivery.Article
+
+		if inProgress[article.ID] {
+			continue
+		}
+
+		summary, ok := summaries[article.ID]
+		if !ok {
+			summary, err = n.extractSummary(ctx, article)
+			if errors.Is(err, errSummaryInProgress) {
+				inProgress[article.ID] = true
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			summaries[article.ID] = summary
+		}
+
+		if err := n.sendArticle(ctx, delivery.UserID, article, summary); err != nil {
+			// Пользователь мог заблокировать бота, повторная отправка не поможет, поэтому статья все равно отмечается доставленной
+			logrus.Errorf("failed to send article %d to subscriber %d: %v", article.ID, delivery.UserID, err)
+		}
+
+		if err := n.subscriptions.MarkDelivered(ctx, delivery.UserID, article.ID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (n *Notifier) sendSubscriptionsLogged(ctx context.Context) { // Ошибка отправки подписчикам не должна останавливать публикацию в канал
+	if err := n.SendSubscriptions(ctx); err != nil {
+		logrus.Errorf("failed to send subscriptions: %v", err)
+	}
+}
@@ -0,0 +1,168 @@
+package roles
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/sirupsen/logrus"
+
+	"github.com/speeddem0n/GoNewsBot/internal/botkit"
+	"github.com/speeddem0n/GoNewsBot/internal/models"
+)
+
+const refreshRetryInterval = time.Minute // Через сколько повторить загрузку администраторов после ошибки телеграма
+
+type RoleStorage interface { // Интерфейс для работы со слоем storage/role.go
+	UserRole(ctx context.Context, userID int64) (models.Role, error)
+	Grant(ctx context.Context, role models.UserRole) error
+	Revoke(ctx context.Context, userID int64) error
+}
+
+type Manager struct { // Структура для определения ролей пользователей
+	bot             botkit.Messenger
+	storage         RoleStorage
+	channelID       int64         // ID канала, администраторы канала получают роль admin
+	refreshInterval time.Duration // Интервал с которым обновляется список администраторов канала
+
+	mu     sync.RWMutex
+	admins map[int64]models.Role // Кэш администраторов канала, пока он не загружен роли берутся только из БД
+}
+
+func NewManager(
+	bot botkit.Messenger,
+	storage RoleStorage,
+	channelID int64,
+	refreshInterval time.Duration,
+) *Manager { // Конструктор для структуры Manager
+	return &Manager{
+		bot:             bot,
+		storage:         storage,
+		channelID:       channelID,
+		refreshInterval: refreshInterval,
+		admins:          make(map[int64]models.Role),
+	}
+}
+
+func (m *Manager) Start(ctx context.Context) error { // Метод для периодического обновления списка администраторов в отдельной горутине
+	ticker := time.NewTicker(m.nextRefresh(m.Refresh(ctx))) // Создаем тикер, после ошибки следующая попытка будет раньше
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			ticker.Reset(m.nextRefresh(m.Refresh(ctx)))
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (m *Manager) nextRefresh(err error) time.Duration { // Метод возвращает через сколько обновить список администраторов, ошибка телеграма не останавливает обновление
+	if err == nil {
+		return m.refreshInterval
+	}
+
+	logrus.Errorf("failed to refresh channel administrators, using stored roles only: %v", err)
+
+	return min(refreshRetryInterval, m.refreshInterval)
+}
+
+func (m *Manager) Refresh(ctx context.Context) error { // Метод для загрузки списка администраторов канала из телеграма
+	members, err := m.bot.ChatAdministrators(m.channelID)
+	if err != nil {
+		return err
+	}
+
+	admins := make(map[int64]models.Role, len(members))
+	for _, member := range members {
+		if role := roleFromMember(member); role != models.RoleNone {
+			admins[member.User.ID] = role
+		}
+	}
+
+	m.mu.Lock()
+	m.admins = admins
+	m.mu.Unlock()
+
+	return nil
+}
+
+func (m *Manager) RoleOf(ctx context.Context, userID int64) (models.Role, error) { // Метод возвращает наибольшую из ролей пользователя в канале и в БД
+	adminRole := m.adminRole(userID)
+
+	storedRole, err := m.storage.UserRole(ctx, userID)
+	if err != nil {
+		return adminRole, err
+	}
+
+	if storedRole.AtLeast(adminRole) {
+		return storedRole, nil
+	}
+
+	return adminRole, nil
+}
+
+func (m *Manager) StoredRole(ctx context.Context, userID int64) (models.Role, error) { // Метод возвращает роль выданную пользователю командой /grant
+	return m.storage.UserRole(ctx, userID)
+}
+
+func (m *Manager) Grant(ctx context.Context, userID int64, role models.Role, grantedBy int64) error { // Метод для выдачи роли пользователю
+	return m.storage.Grant(ctx, models.UserRole{
+		UserID:    userID,
+		Role:      role,
+		GrantedBy: grantedBy,
+	})
+}
+
+func (m *Manager) Revoke(ctx context.Context, userID int64) error { // Метод для удаления роли пользователя
+	return m.storage.Revoke(ctx, userID)
+}
+
+func (m *Manager) ViewChatMemberUpdate() botkit.ViewFunc { // View для апдейтов my_chat_member и chat_member, обновляет кэш администраторов без запроса к телеграму
+	return func(ctx context.Context, bot botkit.Messenger, update tgbotapi.Update) error {
+		if update.MyChatMember != nil && update.MyChatMember.Chat.ID == m.channelID { // Изменились права самого бота в канале, перечитываем список целиком
+			return m.Refresh(ctx)
+		}
+
+		if update.ChatMember == nil || update.ChatMember.Chat.ID != m.channelID {
+			return nil
+		}
+
+		member := update.ChatMember.NewChatMember
+
+		m.mu.Lock()
+		defer m.mu.Unlock()
+
+		if role := roleFromMember(member); role != models.RoleNone {
+			m.admins[member.User.ID] = role
+		} else {
+			delete(m.admins, member.User.ID)
+		}
+
+		return nil
+	}
+}
+
+func (m *Manager) adminRole(userID int64) models.Role { // Метод возвращает роль пользователя в канале из кэша, кэш обновляют только Start и апдейты chat_member
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.admins[userID]
+}
+
+func roleFromMember(member tgbotapi.ChatMember) models.Role { // Функция для получения роли по статусу участника канала
+	if member.User == nil {
+		return models.RoleNone
+	}
+
+	switch {
+	case member.IsCreator():
+		return models.RoleOwner
+	case member.IsAdministrator():
+		return models.RoleAdmin
+	default:
+		return models.RoleNone
+	}
+}
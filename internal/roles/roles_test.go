@@ -0,0 +1,175 @@
+package roles
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"github.com/speeddem0n/GoNewsBot/internal/botkit/botkittest"
+	"github.com/speeddem0n/GoNewsBot/internal/models"
+)
+
+const channelID = -100500
+
+type memoryRoles struct { // Хранилище ролей в памяти вместо storage.RoleStorage
+	mu    sync.Mutex
+	roles map[int64]models.Role
+}
+
+func (s *memoryRoles) UserRole(ctx context.Context, userID int64) (models.Role, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.roles[userID], nil
+}
+
+func (s *memoryRoles) Grant(ctx context.Context, role models.UserRole) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.roles[role.UserID] = role.Role
+
+	return nil
+}
+
+func (s *memoryRoles) Revoke(ctx context.Context, userID int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.roles, userID)
+
+	return nil
+}
+
+func member(userID int64, status string) tgbotapi.ChatMember {
+	return tgbotapi.ChatMember{User: &tgbotapi.User{ID: userID}, Status: status}
+}
+
+func newTestManager() (*Manager, *botkittest.FakeMessenger) {
+	messenger := botkittest.NewFakeMessenger()
+	messenger.SetAdministrators(channelID, member(1, "creator"), member(2, "administrator"), member(3, "member"))
+
+	storage := &memoryRoles{roles: map[int64]models.Role{
+		2: models.RoleEditor,
+		4: models.RoleEditor,
+		5: models.RoleOwner,
+	}}
+
+	return NewManager(messenger, storage, channelID, time.Hour), messenger
+}
+
+func TestRoleOf(t *testing.T) {
+	manager, _ := newTestManager()
+	ctx := context.Background()
+
+	if err := manager.Refresh(ctx); err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		userID int64
+		want   models.Role
+	}{
+		{name: "channel creator", userID: 1, want: models.RoleOwner},
+		{name: "channel admin wins over stored editor", userID: 2, want: models.RoleAdmin},
+		{name: "channel member", userID: 3, want: models.RoleNone},
+		{name: "stored editor", userID: 4, want: models.RoleEditor},
+		{name: "stored owner", userID: 5, want: models.RoleOwner},
+		{name: "stranger", userID: 6, want: models.RoleNone},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := manager.RoleOf(ctx, tt.userID)
+			if err != nil {
+				t.Fatalf("RoleOf(%d) error = %v", tt.userID, err)
+			}
+			if got != tt.want {
+				t.Errorf("RoleOf(%d) = %q, want %q", tt.userID, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRoleOfDoesNotLoadAdministrators(t *testing.T) {
+	manager, messenger := newTestManager()
+	messenger.Err = errors.New("telegram is unavailable")
+
+	got, err := manager.RoleOf(context.Background(), 2) // Без загруженного кэша роль берется только из БД, телеграм не запрашивается
+	if err != nil {
+		t.Fatalf("RoleOf() error = %v", err)
+	}
+	if got != models.RoleEditor {
+		t.Errorf("RoleOf() = %q, want stored role %q", got, models.RoleEditor)
+	}
+
+	messenger.Err = nil
+
+	if got, _ := manager.RoleOf(context.Background(), 1); got != models.RoleNone {
+		t.Errorf("RoleOf() before refresh = %q, want %q", got, models.RoleNone)
+	}
+}
+
+func TestStartLoadsAdministrators(t *testing.T) {
+	manager, _ := newTestManager()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- manager.Start(ctx) }()
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		if role, _ := manager.RoleOf(ctx, 1); role == models.RoleOwner {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("Start() did not load channel administrators")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Errorf("Start() error = %v, want context.Canceled", err)
+	}
+}
+
+func TestViewChatMemberUpdate(t *testing.T) {
+	manager, messenger := newTestManager()
+	ctx := context.Background()
+	view := manager.ViewChatMemberUpdate()
+
+	if err := manager.Refresh(ctx); err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+
+	update := func(chatID int64, m tgbotapi.ChatMember) tgbotapi.Update {
+		return tgbotapi.Update{ChatMember: &tgbotapi.ChatMemberUpdated{Chat: tgbotapi.Chat{ID: chatID}, NewChatMember: m}}
+	}
+
+	if err := view(ctx, messenger, update(channelID, member(3, "administrator"))); err != nil {
+		t.Fatalf("view() error = %v", err)
+	}
+	if got, _ := manager.RoleOf(ctx, 3); got != models.RoleAdmin {
+		t.Errorf("promoted member role = %q, want %q", got, models.RoleAdmin)
+	}
+
+	if err := view(ctx, messenger, update(channelID, member(2, "left"))); err != nil {
+		t.Fatalf("view() error = %v", err)
+	}
+	if got, _ := manager.RoleOf(ctx, 2); got != models.RoleEditor {
+		t.Errorf("demoted admin role = %q, want stored role %q", got, models.RoleEditor)
+	}
+
+	if err := view(ctx, messenger, update(-42, member(6, "administrator"))); err != nil { // Администраторы других чатов ролей не получают
+		t.Fatalf("view() error = %v", err)
+	}
+	if got, _ := manager.RoleOf(ctx, 6); got != models.RoleNone {
+		t.Errorf("admin of another chat role = %q, want %q", got, models.RoleNone)
+	}
+}
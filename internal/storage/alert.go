@@ -0,0 +1,100 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/samber/lo"
+	"github.com/speeddem0n/GoNewsBot/internal/models"
+)
+
+type AlertPostgresStorage struct { // Структура Хранилища алертов принимает подключение к бд
+	db *sqlx.DB
+}
+
+func NewAlertStorage(db *sqlx.DB) *AlertPostgresStorage { // Конструктор для структуры AlertPostgresStorage
+	return &AlertPostgresStorage{db: db}
+}
+
+type dbAlert struct { // Внутренний тип для работы с базой данных
+	ID         int64     `db:"id"`
+	UserID     int64     `db:"user_id"`
+	Expression string    `db:"expression"`
+	Created    time.Time `db:"created"`
+}
+
+func (s *AlertPostgresStorage) Add(ctx context.Context, alert models.Alert) (int64, error) { // Метод для добавления алерта
+	conn, err := s.db.Connx(ctx) // Получаем соеденение с БД
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+
+	var id int64
+
+	row := conn.QueryRowxContext(ctx, `INSERT INTO alert (user_id, expression) VALUES ($1, $2) RETURNING id`, // Выполняем sql запрос для добавления алерта
+		alert.UserID,
+		alert.Expression,
+	)
+
+	if err := row.Err(); err != nil {
+		return 0, err
+	}
+
+	if err := row.Scan(&id); err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (s *AlertPostgresStorage) UserAlerts(ctx context.Context, userID int64) ([]models.Alert, error) { // Метод для получения алертов пользователя
+	conn, err := s.db.Connx(ctx) // Получаем соеденение с БД
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	var alerts []dbAlert
+	if err := conn.SelectContext(ctx, &alerts, `SELECT * FROM alert WHERE user_id = $1 ORDER BY id`, userID); err != nil { // Выполняем sql запрос для получения алертов пользователя
+		return nil, err
+	}
+
+	return lo.Map(alerts, func(alert dbAlert, _ int) models.Alert { return models.Alert(alert) }), nil // Мапим структуру dbAlert в models.Alert
+}
+
+func (s *AlertPostgresStorage) Alerts(ctx context.Context) ([]models.Alert, error) { // Метод для получения алертов всех пользователей
+	conn, err := s.db.Connx(ctx) // Получаем соеденение с БД
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	var alerts []dbAlert
+	if err := conn.SelectContext(ctx, &alerts, `SELECT * FROM alert`); err != nil { // Выполняем sql запрос для получения всех алертов
+		return nil, err
+	}
+
+	return lo.Map(alerts, func(alert dbAlert, _ int) models.Alert { return models.Alert(alert) }), nil
+}
+
+func (s *AlertPostgresStorage) Remove(ctx context.Context, userID, id int64) (bool, error) { // Метод для удаления алерта пользователя, возвращает false если алерта нет
+	conn, err := s.db.Connx(ctx) // Получаем соеденение с БД
+	if err != nil {
+		return false, err
+	}
+	defer conn.Close()
+
+	result, err := conn.ExecContext(ctx, `DELETE FROM alert WHERE id = $1 AND user_id = $2`, id, userID) // Выполняем sql запрос для удаления алерта
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected > 0, nil
+}
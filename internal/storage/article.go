@@ -3,6 +3,7 @@ package storage
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"time"
 
 	"github.com/jmoiron/sqlx"
@@ -19,36 +20,72 @@ func NewArticleStorage(db *sqlx.DB) *ArticlePostgresStorage { // Констру
 }
 
 type dbArticle struct { // Внутренний тип для работы с базой данных
-	ID        int64        `db:"id"`
-	SourceID  int64        `db:"source_id"`
-	Title     string       `db:"title"`
-	Link      string       `db:"link"`
-	Summary   string       `db:"summary"`
-	Published time.Time    `db:"published"`
-	Posted    sql.NullTime `db:"posted"`
-	Created   time.Time    `db:"created"`
+	ID         int64        `db:"id"`
+	SourceID   int64        `db:"source_id"`
+	SourceName string       `db:"source_name"`
+	Title      string       `db:"title"`
+	Link       string       `db:"link"`
+	Summary    string       `db:"summary"`
+	Published  time.Time    `db:"published"`
+	Posted     sql.NullTime `db:"posted"`
+	Created    time.Time    `db:"created"`
+
+	GeneratedSummary  string       `db:"generated_summary"`
+	SummaryTitle      string       `db:"summary_title"`
+	SummaryProvider   string       `db:"summary_provider"`
+	SummaryModel      string       `db:"summary_model"`
+	SummaryPromptHash string       `db:"summary_prompt_hash"`
+	SummarizedAt      sql.NullTime `db:"summarized_at"`
+}
+
+func (a dbArticle) toModel() models.Article { // Метод для преобразования dbArticle в models.Article
+	return models.Article{
+		ID:         a.ID,
+		SourceID:   a.SourceID,
+		SourceName: a.SourceName,
+		Title:      a.Title,
+		Link:       a.Link,
+		Summary:    a.Summary,
+		Published:  a.Published,
+		Posted:     a.Posted.Time,
+		Created:    a.Created,
+		GeneratedSummary: models.GeneratedSummary{
+			Text:       a.GeneratedSummary,
+			Title:      a.SummaryTitle,
+			Provider:   a.SummaryProvider,
+			Model:      a.SummaryModel,
+			PromptHash: a.SummaryPromptHash,
+			Created:    a.SummarizedAt.Time,
+		},
+	}
 }
 
-func (s *ArticlePostgresStorage) Store(ctx context.Context, article models.Article) error { // Метод Store для сохранения статьи в бд
+func (s *ArticlePostgresStorage) Store(ctx context.Context, article models.Article) (int64, error) { // Метод Store для сохранения статьи в бд, возвращает 0 если статья уже была сохранена
 	conn, err := s.db.Connx(ctx) // Получаем соеденение с БД
 	if err != nil {
-		return err
+		return 0, err
 	}
 	defer conn.Close()
 
-	if _, err := conn.ExecContext(ctx, `INSERT INTO article (source_id, title, link, summary, published) 
+	var id int64
+
+	if err := conn.GetContext(ctx, &id, `INSERT INTO article (source_id, title, link, summary, published) 
 	VALUES ($1, $2, $3, $4, $5)
-	ON CONFLICT DO NOTHING`, // Выолняем sql запрос для добавления статьи в БД
+	ON CONFLICT DO NOTHING
+	RETURNING id`, // Выолняем sql запрос для добавления статьи в БД
 		article.SourceID,
 		article.Title,
 		article.Link,
 		article.Summary,
 		article.Published,
 	); err != nil {
-		return err
+		if errors.Is(err, sql.ErrNoRows) { // При конфликте RETURNING не возвращает строк
+			return 0, nil
+		}
+		return 0, err
 	}
 
-	return nil
+	return id, nil
 }
 
 func (s *ArticlePostgresStorage) AllNotPosted(ctx context.Context, since time.Time, limit uint64) ([]models.Article, error) { // Метод AllNotPosted возвращает все статьи которые не были опубликованы в тг канал начиная с определенного времени
@@ -61,12 +98,19 @@ func (s *ArticlePostgresStorage) AllNotPosted(ctx context.Context, since time.Ti
 	var articles []dbArticle
 	if err := conn.SelectContext(ctx, &articles, `SELECT a.id AS id,
 	s.id AS source_id,
+	s.name AS source_name,
 	a.title AS title,
 	a.link AS link,
 	a.summary AS summary,
 	a.published AS published,
 	a.posted AS posted,
-	a.created AS created
+	a.created AS created,
+	a.generated_summary AS generated_summary,
+	a.summary_title AS summary_title,
+	a.summary_provider AS summary_provider,
+	a.summary_model AS summary_model,
+	a.summary_prompt_hash AS summary_prompt_hash,
+	a.summarized_at AS summarized_at
 	FROM article a JOIN source s ON s.id = a.source_id 
 	WHERE a.posted IS NULL 
 	AND a.published >= $1::timestamp 
@@ -78,17 +122,219 @@ func (s *ArticlePostgresStorage) AllNotPosted(ctx context.Context, since time.Ti
 		return nil, err
 	}
 
-	return lo.Map(articles, func(article dbArticle, _ int) models.Article { // Мапим структуру dbArticle в models.Article
-		return models.Article{
-			ID:        article.ID,
-			SourceID:  article.SourceID,
-			Title:     article.Title,
-			Link:      article.Link,
-			Summary:   article.Summary,
-			Published: article.Published,
-			Created:   article.Created,
-		}
-	}), nil
+	return lo.Map(articles, func(article dbArticle, _ int) models.Article { return article.toModel() }), nil // Мапим структуру dbArticle в models.Article
+}
+
+func (s *ArticlePostgresStorage) AllNotSummarized(ctx context.Context, since time.Time, provider string, limit uint64) ([]models.Article, error) { // Метод AllNotSummarized возвращает неопубликованные статьи без саммари или с саммари другого провайдера, статьи без саммари идут первыми в том порядке, в котором их будет публиковать notifier
+	conn, err := s.db.Connx(ctx) // Получаем соеденение с БД
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	var articles []dbArticle
+	if err := conn.SelectContext(ctx, &articles, `SELECT a.id, a.source_id, s.name AS source_name, a.title, a.link, a.summary, a.published, a.posted, a.created,
+	a.generated_summary, a.summary_title, a.summary_provider, a.summary_model, a.summary_prompt_hash, a.summarized_at
+	FROM article a JOIN source s ON s.id = a.source_id
+	WHERE a.posted IS NULL
+	AND (a.summarized_at IS NULL OR ($4::text <> '' AND a.summary_provider <> $4::text))
+	AND (a.summary_claimed_until IS NULL OR a.summary_claimed_until < $3::timestamp)
+	AND a.published >= $1::timestamp
+	ORDER BY a.summarized_at IS NOT NULL, a.created DESC
+	LIMIT $2`, // Выолняем sql запрос для получения статей без саммари основного провайдера, статьи которые сейчас обрабатываются пропускаются
+		since.UTC().Format(time.RFC3339),
+		limit,
+		time.Now().UTC().Format(time.RFC3339),
+		provider,
+	); err != nil {
+		return nil, err
+	}
+
+	return lo.Map(articles, func(article dbArticle, _ int) models.Article { return article.toModel() }), nil // Мапим структуру dbArticle в models.Article
+}
+
+func (s *ArticlePostgresStorage) SaveGeneratedSummary(ctx context.Context, id int64, summary models.GeneratedSummary) error { // Метод SaveGeneratedSummary сохраняет саммари статьи чтобы повторные публикации не обращались к провайдеру
+	conn, err := s.db.Connx(ctx) // Получаем соеденение с БД
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, `UPDATE article SET generated_summary = $1,
+	summary_title = $2,
+	summary_provider = $3,
+	summary_model = $4,
+	summary_prompt_hash = $5,
+	summarized_at = $6::timestamp
+	WHERE id = $7`, // Выолняем sql запрос UPDATE для сохранения саммари
+		summary.Text,
+		summary.Title,
+		summary.Provider,
+		summary.Model,
+		summary.PromptHash,
+		summary.Created.UTC().Format(time.RFC3339),
+		id,
+	); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *ArticlePostgresStorage) ClaimSummary(ctx context.Context, id int64, summarized, until time.Time) (bool, error) { // Метод ClaimSummary занимает статью для построения саммари до времени until, возвращает false если статью уже обрабатывает другой воркер или ее саммари изменилось после summarized
+	conn, err := s.db.Connx(ctx) // Получаем соеденение с БД
+	if err != nil {
+		return false, err
+	}
+	defer conn.Close()
+
+	res, err := conn.ExecContext(ctx, `UPDATE article SET summary_claimed_until = $1::timestamp
+	WHERE id = $2
+	AND (summary_claimed_until IS NULL OR summary_claimed_until < $3::timestamp)
+	AND summarized_at IS NOT DISTINCT FROM $4::timestamp`, // Просроченная отметка значит что воркер упал, такую статью можно занять снова
+		until.UTC().Format(time.RFC3339),
+		id,
+		time.Now().UTC().Format(time.RFC3339),
+		sql.NullTime{Time: summarized.UTC(), Valid: !summarized.IsZero()}, // Саммари уже построено другим воркером пока статья ждала своей очереди
+	)
+	if err != nil {
+		return false, err
+	}
+
+	claimed, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return claimed > 0, nil
+}
+
+func (s *ArticlePostgresStorage) ReleaseSummary(ctx context.Context, id int64) error { // Метод ReleaseSummary снимает отметку ClaimSummary после построения саммари
+	conn, err := s.db.Connx(ctx) // Получаем соеденение с БД
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, `UPDATE article SET summary_claimed_until = NULL WHERE id = $1`, id); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *ArticlePostgresStorage) Search(ctx context.Context, query models.ArticleQuery) ([]models.Article, error) { // Метод Search для полнотекстового поиска статей, результаты отсортированы по релевантности и дате публикации
+	conn, err := s.db.Connx(ctx) // Получаем соеденение с БД
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	since := sql.NullTime{Time: query.Since.UTC(), Valid: !query.Since.IsZero()}
+
+	var articles []dbArticle
+	if err := conn.SelectContext(ctx, &articles, `SELECT a.id AS id,
+	a.source_id AS source_id,
+	a.title AS title,
+	a.link AS link,
+	a.summary AS summary,
+	a.published AS published,
+	a.posted AS posted,
+	a.created AS created
+	FROM article a JOIN source s ON s.id = a.source_id
+	WHERE ($1 = '' OR a.search_vector @@ (websearch_to_tsquery('russian', $1) || websearch_to_tsquery('english', $1)))
+	AND ($2 = 0 OR a.source_id = $2)
+	AND ($3 = '' OR strpos(lower(s.name), lower($3)) > 0)
+	AND ($4::timestamp IS NULL OR a.published >= $4::timestamp)
+	ORDER BY CASE WHEN $1 = '' THEN 0
+		ELSE ts_rank(a.search_vector, websearch_to_tsquery('russian', $1) || websearch_to_tsquery('english', $1))
+	END DESC, a.published DESC
+	LIMIT $5 OFFSET $6`, // Выолняем sql запрос для поиска статей, запрос ищется в русской и английской конфигурации
+		query.Text,
+		query.SourceID,
+		query.SourceName,
+		since,
+		query.Limit,
+		query.Offset,
+	); err != nil {
+		return nil, err
+	}
+
+	return lo.Map(articles, func(article dbArticle, _ int) models.Article { return article.toModel() }), nil // Мапим структуру dbArticle в models.Article
+}
+
+func (s *ArticlePostgresStorage) Latest(ctx context.Context, limit, offset uint64) ([]models.Article, error) { // Метод Latest возвращает последние статьи всех источников, начиная с самой свежей
+	conn, err := s.db.Connx(ctx) // Получаем соеденение с БД
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	var articles []dbArticle
+	if err := conn.SelectContext(ctx, &articles, `SELECT id, source_id, title, link, summary, published, posted, created
+	FROM article
+	ORDER BY published DESC, id DESC
+	LIMIT $1 OFFSET $2`, // Выолняем sql запрос для получения последних статей
+		limit,
+		offset,
+	); err != nil {
+		return nil, err
+	}
+
+	return lo.Map(articles, func(article dbArticle, _ int) models.Article { return article.toModel() }), nil // Мапим структуру dbArticle в models.Article
+}
+
+func (s *ArticlePostgresStorage) BySource(ctx context.Context, sourceID int64, limit, offset uint64) ([]models.Article, error) { // Метод BySource возвращает последние статьи источника, начиная с самой свежей
+	conn, err := s.db.Connx(ctx) // Получаем соеденение с БД
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	var articles []dbArticle
+	if err := conn.SelectContext(ctx, &articles, `SELECT id, source_id, title, link, summary, published, posted, created
+	FROM article
+	WHERE source_id = $1
+	ORDER BY published DESC, id DESC
+	LIMIT $2 OFFSET $3`, // Выолняем sql запрос для получения статей источника
+		sourceID,
+		limit,
+		offset,
+	); err != nil {
+		return nil, err
+	}
+
+	return lo.Map(articles, func(article dbArticle, _ int) models.Article { return article.toModel() }), nil // Мапим структуру dbArticle в models.Article
+}
+
+func (s *ArticlePostgresStorage) SourceStats(ctx context.Context, sourceID int64) (models.SourceStats, error) { // Метод SourceStats возвращает количество статей источника и дату самой свежей из них
+	conn, err := s.db.Connx(ctx) // Получаем соеденение с БД
+	if err != nil {
+		return models.SourceStats{}, err
+	}
+	defer conn.Close()
+
+	var stats struct {
+		Articles      int          `db:"articles"`
+		Posted        int          `db:"posted"`
+		LastPublished sql.NullTime `db:"last_published"`
+	}
+
+	if err := conn.GetContext(ctx, &stats, `SELECT COUNT(*) AS articles,
+	COUNT(posted) AS posted,
+	MAX(published) AS last_published
+	FROM article
+	WHERE source_id = $1`, // Выолняем sql запрос для подсчета статей источника
+		sourceID,
+	); err != nil {
+		return models.SourceStats{}, err
+	}
+
+	return models.SourceStats{
+		Articles:      stats.Articles,
+		Posted:        stats.Posted,
+		LastPublished: stats.LastPublished.Time,
+	}, nil
 }
 
 func (s *ArticlePostgresStorage) MarkPosted(ctx context.Context, id int64) error { // Метод MarkPosted для отметки статьи как уже опубликованую
@@ -0,0 +1,90 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/samber/lo"
+	"github.com/speeddem0n/GoNewsBot/internal/models"
+)
+
+type BookmarkPostgresStorage struct { // Структура Хранилища закладок пользователей принимает подключение к бд
+	db *sqlx.DB
+}
+
+func NewBookmarkStorage(db *sqlx.DB) *BookmarkPostgresStorage { // Конструктор для структуры BookmarkPostgresStorage
+	return &BookmarkPostgresStorage{db: db}
+}
+
+func (s *BookmarkPostgresStorage) Add(ctx context.Context, userID, articleID int64) (bool, error) { // Метод для сохранения статьи в закладки пользователя, возвращает false если статья уже сохранена
+	conn, err := s.db.Connx(ctx) // Получаем соеденение с БД
+	if err != nil {
+		return false, err
+	}
+	defer conn.Close()
+
+	result, err := conn.ExecContext(ctx, `INSERT INTO bookmark (user_id, article_id) VALUES ($1, $2) ON CONFLICT DO NOTHING`, // Выполняем sql запрос для добавления закладки
+		userID,
+		articleID,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected > 0, nil
+}
+
+func (s *BookmarkPostgresStorage) Remove(ctx context.Context, userID, articleID int64) (bool, error) { // Метод для удаления закладки, возвращает false если закладки не было
+	conn, err := s.db.Connx(ctx) // Получаем соеденение с БД
+	if err != nil {
+		return false, err
+	}
+	defer conn.Close()
+
+	result, err := conn.ExecContext(ctx, `DELETE FROM bookmark WHERE user_id = $1 AND article_id = $2`, userID, articleID) // Выполняем sql запрос для удаления закладки
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected > 0, nil
+}
+
+func (s *BookmarkPostgresStorage) Bookmarks(ctx context.Context, userID int64, limit, offset uint64) ([]models.Article, error) { // Метод возвращает сохраненные пользователем статьи, начиная с последней сохраненной
+	conn, err := s.db.Connx(ctx) // Получаем соеденение с БД
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	var articles []dbArticle
+	if err := conn.SelectContext(ctx, &articles, `SELECT a.id AS id,
+	a.source_id AS source_id,
+	a.title AS title,
+	a.link AS link,
+	a.summary AS summary,
+	a.published AS published,
+	a.posted AS posted,
+	a.created AS created
+	FROM bookmark b JOIN article a ON a.id = b.article_id
+	WHERE b.user_id = $1
+	ORDER BY b.created DESC, a.id DESC
+	LIMIT $2 OFFSET $3`, // Выполняем sql запрос для получения закладок пользователя
+		userID,
+		limit,
+		offset,
+	); err != nil {
+		return nil, err
+	}
+
+	return lo.Map(articles, func(article dbArticle, _ int) models.Article { return article.toModel() }), nil // Мапим структуру dbArticle в models.Article
+}
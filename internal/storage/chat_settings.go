@@ -0,0 +1,67 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type ChatSettingsPostgresStorage struct { // Структура Хранилища настроек чатов принимает подключение к бд
+	db *sqlx.DB
+}
+
+func NewChatSettingsStorage(db *sqlx.DB) *ChatSettingsPostgresStorage { // Конструктор для структуры ChatSettingsPostgresStorage
+	return &ChatSettingsPostgresStorage{db: db}
+}
+
+func (s *ChatSettingsPostgresStorage) ChatLocale(ctx context.Context, chatID int64) (string, error) { // Метод для получения языка чата, если язык не задан возвращает пустую строку
+	conn, err := s.db.Connx(ctx) // Получаем соеденение с БД
+	if err != nil {
+		return "", err
+	}
+	defer conn.Close()
+
+	var locale string
+	if err := conn.GetContext(ctx, &locale, `SELECT locale FROM chat_settings WHERE chat_id = $1`, chatID); err != nil { // Выполняем sql запрос для получения языка чата
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", nil
+		}
+		return "", err
+	}
+
+	return locale, nil
+}
+
+func (s *ChatSettingsPostgresStorage) SetChatLocale(ctx context.Context, chatID int64, locale string) error { // Метод для сохранения языка чата
+	conn, err := s.db.Connx(ctx) // Получаем соеденение с БД
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, `INSERT INTO chat_settings (chat_id, locale) VALUES ($1, $2)
+	ON CONFLICT (chat_id) DO UPDATE SET locale = EXCLUDED.locale, updated = NOW()`, // Выполняем sql запрос для сохранения языка
+		chatID,
+		locale,
+	); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *ChatSettingsPostgresStorage) ResetChatLocale(ctx context.Context, chatID int64) error { // Метод для удаления языка чата, бот снова использует язык пользователя
+	conn, err := s.db.Connx(ctx) // Получаем соеденение с БД
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, `DELETE FROM chat_settings WHERE chat_id = $1`, chatID); err != nil { // Выполняем sql запрос для удаления языка
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE user_role
+(
+    user_id BIGINT PRIMARY KEY,
+    role VARCHAR(16) NOT NULL,
+    granted_by BIGINT NOT NULL,
+    created TIMESTAMP NOT NULL DEFAULT NOW()
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS user_role;
+-- +goose StatementEnd
@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE chat_settings
+(
+    chat_id BIGINT PRIMARY KEY,
+    locale VARCHAR(8) NOT NULL,
+    updated TIMESTAMP NOT NULL DEFAULT NOW()
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS chat_settings;
+-- +goose StatementEnd
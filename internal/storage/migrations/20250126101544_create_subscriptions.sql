@@ -0,0 +1,32 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE subscription
+(
+    user_id BIGINT NOT NULL,
+    source_id INT NOT NULL,
+    created TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, source_id),
+    CONSTRAINT fk_subscription_source_id
+        FOREIGN KEY (source_id)
+            REFERENCES source (id)
+            ON DELETE CASCADE
+);
+
+CREATE TABLE subscription_delivery
+(
+    user_id BIGINT NOT NULL,
+    article_id INT NOT NULL,
+    delivered TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, article_id),
+    CONSTRAINT fk_subscription_delivery_article_id
+        FOREIGN KEY (article_id)
+            REFERENCES article (id)
+            ON DELETE CASCADE
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS subscription_delivery;
+DROP TABLE IF EXISTS subscription;
+-- +goose StatementEnd
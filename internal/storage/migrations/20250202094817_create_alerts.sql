@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE alert
+(
+    id SERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL,
+    expression VARCHAR(255) NOT NULL,
+    created TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_alert_user_id ON alert (user_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS alert;
+-- +goose StatementEnd
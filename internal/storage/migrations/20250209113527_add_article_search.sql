@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE article ADD COLUMN search_vector TSVECTOR GENERATED ALWAYS AS (
+    setweight(to_tsvector('russian', title), 'A') ||
+    setweight(to_tsvector('english', title), 'A') ||
+    setweight(to_tsvector('russian', summary), 'B') ||
+    setweight(to_tsvector('english', summary), 'B')
+) STORED;
+
+CREATE INDEX idx_article_search_vector ON article USING GIN (search_vector);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_article_search_vector;
+ALTER TABLE article DROP COLUMN IF EXISTS search_vector;
+-- +goose StatementEnd
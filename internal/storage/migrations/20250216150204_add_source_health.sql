@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE source
+    ADD COLUMN last_fetched TIMESTAMP,
+    ADD COLUMN last_error TEXT NOT NULL DEFAULT '',
+    ADD COLUMN error_count INT NOT NULL DEFAULT 0;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE source
+    DROP COLUMN IF EXISTS last_fetched,
+    DROP COLUMN IF EXISTS last_error,
+    DROP COLUMN IF EXISTS error_count;
+-- +goose StatementEnd
@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE bookmark
+(
+    user_id BIGINT NOT NULL,
+    article_id INT NOT NULL,
+    created TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, article_id),
+    CONSTRAINT fk_bookmark_article_id
+        FOREIGN KEY (article_id)
+            REFERENCES article (id)
+            ON DELETE CASCADE
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS bookmark;
+-- +goose StatementEnd
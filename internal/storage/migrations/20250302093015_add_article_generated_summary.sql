@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE article
+    ADD COLUMN generated_summary TEXT NOT NULL DEFAULT '',
+    ADD COLUMN summary_provider TEXT NOT NULL DEFAULT '',
+    ADD COLUMN summary_model TEXT NOT NULL DEFAULT '',
+    ADD COLUMN summary_prompt_hash TEXT NOT NULL DEFAULT '',
+    ADD COLUMN summarized_at TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS article_not_summarized_idx ON article (created DESC) WHERE posted IS NULL AND summarized_at IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS article_not_summarized_idx;
+
+ALTER TABLE article
+    DROP COLUMN IF EXISTS generated_summary,
+    DROP COLUMN IF EXISTS summary_provider,
+    DROP COLUMN IF EXISTS summary_model,
+    DROP COLUMN IF EXISTS summary_prompt_hash,
+    DROP COLUMN IF EXISTS summarized_at;
+-- +goose StatementEnd
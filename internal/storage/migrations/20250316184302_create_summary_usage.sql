@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS summary_usage
+(
+    day               DATE           NOT NULL,
+    source_id         BIGINT         NOT NULL DEFAULT 0,
+    chat_id           BIGINT         NOT NULL DEFAULT 0,
+    provider          TEXT           NOT NULL,
+    model             TEXT           NOT NULL DEFAULT '',
+    requests          INT            NOT NULL DEFAULT 0,
+    prompt_tokens     BIGINT         NOT NULL DEFAULT 0,
+    completion_tokens BIGINT         NOT NULL DEFAULT 0,
+    cost              NUMERIC(14, 6) NOT NULL DEFAULT 0,
+    PRIMARY KEY (day, source_id, chat_id, provider, model)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS summary_usage;
+-- +goose StatementEnd
@@ -0,0 +1,9 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE article ADD COLUMN summary_claimed_until TIMESTAMP;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE article DROP COLUMN IF EXISTS summary_claimed_until;
+-- +goose StatementEnd
@@ -0,0 +1,77 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/speeddem0n/GoNewsBot/internal/models"
+)
+
+type RolePostgresStorage struct { // Структура Хранилища ролей пользователей принимает подключение к бд
+	db *sqlx.DB
+}
+
+func NewRoleStorage(db *sqlx.DB) *RolePostgresStorage { // Конструктор для структуры RolePostgresStorage
+	return &RolePostgresStorage{db: db}
+}
+
+type dbUserRole struct { // Внутренний тип для работы с базой данных
+	UserID    int64     `db:"user_id"`
+	Role      string    `db:"role"`
+	GrantedBy int64     `db:"granted_by"`
+	Created   time.Time `db:"created"`
+}
+
+func (s *RolePostgresStorage) UserRole(ctx context.Context, userID int64) (models.Role, error) { // Метод для получения роли пользователя, если роли нет возвращает RoleNone
+	conn, err := s.db.Connx(ctx) // Получаем соеденение с БД
+	if err != nil {
+		return models.RoleNone, err
+	}
+	defer conn.Close()
+
+	var role dbUserRole
+	if err := conn.GetContext(ctx, &role, `SELECT * FROM user_role WHERE user_id = $1`, userID); err != nil { // Выполняем sql запрос для получения роли пользователя
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.RoleNone, nil
+		}
+		return models.RoleNone, err
+	}
+
+	return models.Role(role.Role), nil
+}
+
+func (s *RolePostgresStorage) Grant(ctx context.Context, role models.UserRole) error { // Метод для выдачи роли, предыдущая роль пользователя заменяется
+	conn, err := s.db.Connx(ctx) // Получаем соеденение с БД
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, `INSERT INTO user_role (user_id, role, granted_by) VALUES ($1, $2, $3)
+	ON CONFLICT (user_id) DO UPDATE SET role = EXCLUDED.role, granted_by = EXCLUDED.granted_by, created = NOW()`, // Выполняем sql запрос для сохранения роли
+		role.UserID,
+		string(role.Role),
+		role.GrantedBy,
+	); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *RolePostgresStorage) Revoke(ctx context.Context, userID int64) error { // Метод для удаления роли пользователя
+	conn, err := s.db.Connx(ctx) // Получаем соеденение с БД
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, `DELETE FROM user_role WHERE user_id = $1`, userID); err != nil { // Выполняем sql запрос для удаления роли
+		return err
+	}
+
+	return nil
+}
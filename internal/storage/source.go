@@ -2,6 +2,7 @@ package storage
 
 import (
 	"context"
+	"database/sql"
 	"time"
 
 	"github.com/jmoiron/sqlx"
@@ -14,10 +15,25 @@ type SourcePostgresStorage struct { // Структура Хранилища и
 }
 
 type dbSource struct { // Внутренний тип для работы с базой данных
-	ID      int64     `db:"id"`
-	Name    string    `db:"name"`
-	FeedURL string    `db:"feed_url"`
-	Created time.Time `db:"created"`
+	ID          int64        `db:"id"`
+	Name        string       `db:"name"`
+	FeedURL     string       `db:"feed_url"`
+	Created     time.Time    `db:"created"`
+	LastFetched sql.NullTime `db:"last_fetched"`
+	LastError   string       `db:"last_error"`
+	ErrorCount  int          `db:"error_count"`
+}
+
+func (s dbSource) toModel() models.Source { // Метод для преобразования dbSource в models.Source
+	return models.Source{
+		ID:          s.ID,
+		Name:        s.Name,
+		FeedURL:     s.FeedURL,
+		Created:     s.Created,
+		LastFetched: s.LastFetched.Time,
+		LastError:   s.LastError,
+		ErrorCount:  s.ErrorCount,
+	}
 }
 
 func NewSourceStorage(db *sqlx.DB) *SourcePostgresStorage { // Конструктор для стуктуры SourcePostgresStorage
@@ -36,7 +52,7 @@ func (s *SourcePostgresStorage) Sources(ctx context.Context) ([]models.Source, e
 		return nil, err
 	}
 
-	return lo.Map(sources, func(dbSource dbSource, _ int) models.Source { return models.Source(dbSource) }), nil // Мапим структуру dbSource в models.Source
+	return lo.Map(sources, func(dbSource dbSource, _ int) models.Source { return dbSource.toModel() }), nil // Мапим структуру dbSource в models.Source
 }
 
 func (s *SourcePostgresStorage) SourceByID(ctx context.Context, id int64) (*models.Source, error) { // Метод для получения источника по его ID
@@ -51,7 +67,9 @@ func (s *SourcePostgresStorage) SourceByID(ctx context.Context, id int64) (*mode
 		return nil, err
 	}
 
-	return (*models.Source)(&source), nil
+	model := source.toModel()
+
+	return &model, nil
 }
 
 func (s *SourcePostgresStorage) Add(ctx context.Context, source models.Source) (int64, error) { // Метод для добавления источника
@@ -82,6 +100,24 @@ func (s *SourcePostgresStorage) Add(ctx context.Context, source models.Source) (
 	return id, nil
 }
 
+func (s *SourcePostgresStorage) Update(ctx context.Context, source models.Source) error { // Метод для изменения имени и ссылки источника
+	conn, err := s.db.Connx(ctx) // Получаем соеденение с БД
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, `UPDATE source SET name = $1, feed_url = $2 WHERE id = $3`, // Выполняем sql запрос для изменения источника
+		source.Name,
+		source.FeedURL,
+		source.ID,
+	); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (s *SourcePostgresStorage) Delete(ctx context.Context, id int64) error { // Метод для удаления источника
 	conn, err := s.db.Connx(ctx) // Получаем соеденение с БД
 	if err != nil {
@@ -96,3 +132,28 @@ func (s *SourcePostgresStorage) Delete(ctx context.Context, id int64) error { //
 
 	return nil
 }
+
+func (s *SourcePostgresStorage) SetFetchStatus(ctx context.Context, id int64, fetchErr error) error { // Метод для сохранения результата загрузки ленты источника, fetchErr равен nil если загрузка прошла успешно
+	conn, err := s.db.Connx(ctx) // Получаем соеденение с БД
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	var lastError string
+	if fetchErr != nil {
+		lastError = fetchErr.Error()
+	}
+
+	if _, err := conn.ExecContext(ctx, `UPDATE source SET last_fetched = $1, last_error = $2,
+	error_count = CASE WHEN $2 = '' THEN 0 ELSE error_count + 1 END
+	WHERE id = $3`, // Выполняем sql запрос для обновления состояния источника, счетчик ошибок сбрасывается после успешной загрузки
+		time.Now().UTC(),
+		lastError,
+		id,
+	); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,144 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/samber/lo"
+	"github.com/speeddem0n/GoNewsBot/internal/models"
+)
+
+type SubscriptionPostgresStorage struct { // Структура Хранилища подписок пользователей принимает подключение к бд
+	db *sqlx.DB
+}
+
+func NewSubscriptionStorage(db *sqlx.DB) *SubscriptionPostgresStorage { // Конструктор для структуры SubscriptionPostgresStorage
+	return &SubscriptionPostgresStorage{db: db}
+}
+
+type dbDelivery struct { // Внутренний тип для работы с базой данных
+	UserID int64 `db:"user_id"`
+	dbArticle
+}
+
+func (s *SubscriptionPostgresStorage) Subscribe(ctx context.Context, userID, sourceID int64) (bool, error) { // Метод для подписки пользователя на источник, возвращает false если подписка уже есть
+	conn, err := s.db.Connx(ctx) // Получаем соеденение с БД
+	if err != nil {
+		return false, err
+	}
+	defer conn.Close()
+
+	result, err := conn.ExecContext(ctx, `INSERT INTO subscription (user_id, source_id) VALUES ($1, $2) ON CONFLICT DO NOTHING`, // Выполняем sql запрос для добавления подписки
+		userID,
+		sourceID,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected > 0, nil
+}
+
+func (s *SubscriptionPostgresStorage) Unsubscribe(ctx context.Context, userID, sourceID int64) (bool, error) { // Метод для удаления подписки, возвращает false если подписки не было
+	conn, err := s.db.Connx(ctx) // Получаем соеденение с БД
+	if err != nil {
+		return false, err
+	}
+	defer conn.Close()
+
+	result, err := conn.ExecContext(ctx, `DELETE FROM subscription WHERE user_id = $1 AND source_id = $2`, userID, sourceID) // Выполняем sql запрос для удаления подписки
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected > 0, nil
+}
+
+func (s *SubscriptionPostgresStorage) SubscribedSourceIDs(ctx context.Context, userID int64) ([]int64, error) { // Метод для получения ID источников на которые подписан пользователь
+	conn, err := s.db.Connx(ctx) // Получаем соеденение с БД
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	var sourceIDs []int64
+	if err := conn.SelectContext(ctx, &sourceIDs, `SELECT source_id FROM subscription WHERE user_id = $1`, userID); err != nil { // Выполняем sql запрос для получения подписок пользователя
+		return nil, err
+	}
+
+	return sourceIDs, nil
+}
+
+func (s *SubscriptionPostgresStorage) AllNotDelivered(ctx context.Context, since time.Time, limit uint64) ([]models.Delivery, error) { // Метод возвращает статьи из подписок которые еще не были отправлены подписчикам
+	conn, err := s.db.Connx(ctx) // Получаем соеденение с БД
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	var deliveries []dbDelivery
+	if err := conn.SelectContext(ctx, &deliveries, `SELECT sub.user_id AS user_id,
+	a.id AS id,
+	a.source_id AS source_id,
+	src.name AS source_name,
+	a.title AS title,
+	a.link AS link,
+	a.summary AS summary,
+	a.published AS published,
+	a.created AS created,
+	a.generated_summary AS generated_summary,
+	a.summary_title AS summary_title,
+	a.summary_provider AS summary_provider,
+	a.summary_model AS summary_model,
+	a.summary_prompt_hash AS summary_prompt_hash,
+	a.summarized_at AS summarized_at
+	FROM subscription sub
+	JOIN article a ON a.source_id = sub.source_id
+	JOIN source src ON src.id = a.source_id
+	LEFT JOIN subscription_delivery d ON d.user_id = sub.user_id AND d.article_id = a.id
+	WHERE d.article_id IS NULL
+	AND a.created >= sub.created
+	AND a.published >= $1::timestamp
+	ORDER BY a.created
+	LIMIT $2`, // Выполняем sql запрос, статьи полученные до подписки пользователю не отправляются
+		since.UTC().Format(time.RFC3339),
+		limit,
+	); err != nil {
+		return nil, err
+	}
+
+	return lo.Map(deliveries, func(delivery dbDelivery, _ int) models.Delivery { // Мапим структуру dbDelivery в models.Delivery
+		return models.Delivery{
+			UserID:  delivery.UserID,
+			Article: delivery.toModel(),
+		}
+	}), nil
+}
+
+func (s *SubscriptionPostgresStorage) MarkDelivered(ctx context.Context, userID, articleID int64) error { // Метод для отметки статьи как отправленной подписчику
+	conn, err := s.db.Connx(ctx) // Получаем соеденение с БД
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, `INSERT INTO subscription_delivery (user_id, article_id) VALUES ($1, $2) ON CONFLICT DO NOTHING`, // Выполняем sql запрос для сохранения доставки
+		userID,
+		articleID,
+	); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,116 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/samber/lo"
+	"github.com/speeddem0n/GoNewsBot/internal/models"
+)
+
+type UsagePostgresStorage struct { // Структура Хранилища расхода токенов на саммари принимает подключение к бд
+	db *sqlx.DB
+}
+
+func NewUsageStorage(db *sqlx.DB) *UsagePostgresStorage { // Конструктор для структуры UsagePostgresStorage
+	return &UsagePostgresStorage{db: db}
+}
+
+func (s *UsagePostgresStorage) Record(ctx context.Context, usage models.SummaryUsage) error { // Метод добавляет расход одного запроса к суммам за день по источнику, каналу и модели
+	conn, err := s.db.Connx(ctx) // Получаем соеденение с БД
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if usage.Created.IsZero() {
+		usage.Created = time.Now()
+	}
+
+	if _, err := conn.ExecContext( // Выполняем sql запрос для добавления расхода, строка за день создается при первом запросе
+		ctx,
+		`INSERT INTO summary_usage (day, source_id, chat_id, provider, model, requests, prompt_tokens, completion_tokens, cost)
+		VALUES ($1, $2, $3, $4, $5, 1, $6, $7, $8)
+		ON CONFLICT (day, source_id, chat_id, provider, model) DO UPDATE SET
+			requests = summary_usage.requests + 1,
+			prompt_tokens = summary_usage.prompt_tokens + EXCLUDED.prompt_tokens,
+			completion_tokens = summary_usage.completion_tokens + EXCLUDED.completion_tokens,
+			cost = summary_usage.cost + EXCLUDED.cost`,
+		usage.Created.UTC().Format(time.DateOnly),
+		usage.SourceID,
+		usage.ChatID,
+		usage.Provider,
+		usage.Model,
+		usage.PromptTokens,
+		usage.CompletionTokens,
+		usage.Cost,
+	); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *UsagePostgresStorage) Cost(ctx context.Context, since time.Time) (float64, error) { // Метод возвращает сколько потрачено на саммари начиная с since
+	conn, err := s.db.Connx(ctx) // Получаем соеденение с БД
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+
+	var cost float64
+	if err := conn.GetContext(ctx, &cost, `SELECT COALESCE(SUM(cost), 0) FROM summary_usage WHERE day >= $1`, since.UTC().Format(time.DateOnly)); err != nil { // Выполняем sql запрос для подсчета расходов
+		return 0, err
+	}
+
+	return cost, nil
+}
+
+func (s *UsagePostgresStorage) UsageByDay(ctx context.Context, since time.Time) ([]models.UsageTotal, error) { // Метод возвращает расход по дням начиная с since, новые дни первыми
+	return s.totals(ctx, `SELECT to_char(day, 'DD.MM.YYYY') AS key, SUM(requests) AS requests, SUM(prompt_tokens) AS prompt_tokens, SUM(completion_tokens) AS completion_tokens, SUM(cost) AS cost
+		FROM summary_usage
+		WHERE day >= $1
+		GROUP BY day
+		ORDER BY day DESC`, since)
+}
+
+func (s *UsagePostgresStorage) UsageBySource(ctx context.Context, since time.Time) ([]models.UsageTotal, error) { // Метод возвращает расход по источникам начиная с since, самые дорогие первыми
+	return s.totals(ctx, `SELECT COALESCE(src.name, '#' || u.source_id::text) AS key, SUM(u.requests) AS requests, SUM(u.prompt_tokens) AS prompt_tokens, SUM(u.completion_tokens) AS completion_tokens, SUM(u.cost) AS cost
+		FROM summary_usage u
+		LEFT JOIN source src ON src.id = u.source_id
+		WHERE u.day >= $1
+		GROUP BY 1
+		ORDER BY cost DESC, prompt_tokens DESC`, since)
+}
+
+func (s *UsagePostgresStorage) UsageByChat(ctx context.Context, since time.Time) ([]models.UsageTotal, error) { // Метод возвращает расход по каналам начиная с since, самые дорогие первыми
+	return s.totals(ctx, `SELECT chat_id::text AS key, SUM(requests) AS requests, SUM(prompt_tokens) AS prompt_tokens, SUM(completion_tokens) AS completion_tokens, SUM(cost) AS cost
+		FROM summary_usage
+		WHERE day >= $1
+		GROUP BY chat_id
+		ORDER BY cost DESC, prompt_tokens DESC`, since)
+}
+
+func (s *UsagePostgresStorage) totals(ctx context.Context, query string, since time.Time) ([]models.UsageTotal, error) { // Метод выполняет запрос с группировкой расхода
+	conn, err := s.db.Connx(ctx) // Получаем соеденение с БД
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	var totals []dbUsageTotal
+	if err := conn.SelectContext(ctx, &totals, query, since.UTC().Format(time.DateOnly)); err != nil { // Выполняем sql запрос для получения расхода
+		return nil, err
+	}
+
+	return lo.Map(totals, func(total dbUsageTotal, _ int) models.UsageTotal { return models.UsageTotal(total) }), nil
+}
+
+type dbUsageTotal struct { // Структура для суммарного расхода из БД
+	Key              string  `db:"key"`
+	Requests         int     `db:"requests"`
+	PromptTokens     int64   `db:"prompt_tokens"`
+	CompletionTokens int64   `db:"completion_tokens"`
+	Cost             float64 `db:"cost"`
+}
@@ -0,0 +1,222 @@
+package summary
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/speeddem0n/GoNewsBot/internal/models"
+)
+
+const (
+	defaultFailureThreshold = 3               // После скольких ошибок подряд провайдер пропускается
+	defaultCooldown         = 5 * time.Minute // Сколько пропускается провайдер после серии ошибок
+)
+
+var ErrNoSummary = errors.New("no summarizer provider returned a summary") // Ошибка когда ни один провайдер цепочки не вернул саммари
+
+type ChainProvider struct { // Провайдер в цепочке саммари
+	Name       string        // Имя для логов
+	Summarizer Summarizer    // Сам провайдер
+	Timeout    time.Duration // Сколько ждать ответа провайдера, 0 - без ограничения
+	Model      string        // Модель для сохранения вместе с саммари, если не задана берется у провайдера
+	Budget     *Budget       // Месячный бюджет провайдера, после его исчерпания провайдер пропускается, nil - без ограничения
+}
+
+type ChainConfig struct { // Настройки цепочки провайдеров
+	FailureThreshold   int           // После скольких ошибок подряд провайдер пропускается, по умолчанию 3
+	Cooldown           time.Duration // Сколько пропускается провайдер после серии ошибок, по умолчанию 5 минут
+	PostWithoutSummary bool          // Если ни один провайдер не ответил, вернуть пустое саммари вместо ошибки, чтобы статья не блокировала очередь
+	Prompts            *Prompts      // Шаблоны запроса к модели, хэш запроса сохраняется вместе с саммари
+}
+
+type Chain struct { // Провайдер который по очереди опрашивает провайдеров цепочки до первого непустого саммари
+	links              []*chainLink
+	failureThreshold   int
+	cooldown           time.Duration
+	postWithoutSummary bool
+	prompts            *Prompts
+	now                func() time.Time
+}
+
+type chainLink struct { // Провайдер цепочки и состояние его предохранителя
+	ChainProvider
+
+	mu        sync.Mutex
+	failures  int       // Ошибки подряд
+	openUntil time.Time // До какого времени провайдер пропускается
+}
+
+func NewChain(cfg ChainConfig, providers ...ChainProvider) *Chain { // Конструктор для структуры Chain
+	if cfg.FailureThreshold <= 0 {
+		cfg.FailureThreshold = defaultFailureThreshold
+	}
+	if cfg.Cooldown <= 0 {
+		cfg.Cooldown = defaultCooldown
+	}
+
+	links := make([]*chainLink, 0, len(providers))
+	for _, provider := range providers {
+		if named, ok := provider.Summarizer.(interface{ Model() string }); ok && provider.Model == "" {
+			provider.Model = named.Model()
+		}
+		links = append(links, &chainLink{ChainProvider: provider})
+	}
+
+	return &Chain{
+		links:              links,
+		failureThreshold:   cfg.FailureThreshold,
+		cooldown:           cfg.Cooldown,
+		postWithoutSummary: cfg.PostWithoutSummary,
+		prompts:            cfg.Prompts,
+		now:                time.Now,
+	}
+}
+
+func (c *Chain) Summarize(ctx context.Context, text string) (string, error) { // Метод возвращает саммари первого провайдера который ответил без ошибки
+	summary, err := c.Generate(ctx, text)
+	if err != nil {
+		return "", err
+	}
+
+	return summary.Text, nil
+}
+
+func (c *Chain) Generate(ctx context.Context, text string) (models.GeneratedSummary, error) { // Метод возвращает саммари вместе с провайдером и моделью которые его построили
+	var errs []error
+
+	for _, link := range c.links {
+		if !link.available(ctx, c.now()) {
+			continue
+		}
+
+		if _, ok := link.Summarizer.(Disabled); ok { // Провайдер none выключает саммари, следующие провайдеры не опрашиваются
+			return models.GeneratedSummary{}, nil
+		}
+
+		summary, err := c.call(ctx, link, func(ctx context.Context) (string, error) { return link.Summarizer.Summarize(ctx, text) })
+		if ctx.Err() != nil { // Остановка сервиса, а не проблема провайдера
+			return models.GeneratedSummary{}, ctx.Err()
+		}
+
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", link.Name, err))
+			continue
+		}
+
+		if summary != "" {
+			return models.GeneratedSummary{
+				Text:       summary,
+				Title:      c.translateTitle(ctx),
+				Provider:   link.Name,
+				Model:      link.Model,
+				PromptHash: link.promptHash(ctx, c.prompts),
+				Created:    c.now(),
+			}, nil
+		}
+	}
+
+	if c.postWithoutSummary {
+		if len(errs) > 0 {
+			logrus.Warnf("all summarizer providers failed, posting without summary: %v", errors.Join(errs...))
+		}
+		return models.GeneratedSummary{}, nil
+	}
+
+	return models.GeneratedSummary{}, errors.Join(append([]error{ErrNoSummary}, errs...)...)
+}
+
+func (c *Chain) PromptHash(ctx context.Context) string { // Метод возвращает хэш запроса провайдера, который построил бы саммари сейчас, сохраненные саммари с другим хэшем строятся заново
+	if len(c.links) == 0 {
+		return c.prompts.Hash(promptDataFromContext(ctx))
+	}
+
+	for _, link := range c.links { // Пока основной провайдер выключен предохранителем или бюджетом, саммари запасного остается актуальным
+		if link.available(ctx, c.now()) {
+			return link.promptHash(ctx, c.prompts)
+		}
+	}
+
+	return c.links[0].promptHash(ctx, c.prompts)
+}
+
+func (c *Chain) PrimaryProvider() string { // Метод возвращает имя основного провайдера цепочки
+	if len(c.links) == 0 {
+		return ""
+	}
+
+	return c.links[0].Name
+}
+
+func (c *Chain) translateTitle(ctx context.Context) string { // Метод переводит заголовок статьи на язык канала, если он написан на другом языке
+	data := promptDataFromContext(ctx)
+
+	if language := DetectLanguage(data.Title); !c.prompts.Translates() || data.Language == "" || language == "" || language == data.Language {
+		return ""
+	}
+
+	for _, link := range c.links {
+		translator, ok := link.Summarizer.(Translator)
+		if !ok || !link.available(ctx, c.now()) {
+			continue
+		}
+
+		title, err := c.call(ctx, link, func(ctx context.Context) (string, error) { return translator.Translate(ctx, data.Title, data.Language) })
+		if err == nil && title != "" {
+			return title
+		}
+
+		if ctx.Err() != nil {
+			return ""
+		}
+	}
+
+	return "" // Без перевода публикуется только исходный заголовок
+}
+
+func (c *Chain) call(ctx context.Context, link *chainLink, request func(ctx context.Context) (string, error)) (string, error) { // Метод выполняет запрос к провайдеру с его таймаутом и обновляет предохранитель
+	if link.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, link.Timeout)
+		defer cancel()
+	}
+
+	summary, err := request(ctx)
+
+	link.mu.Lock()
+	defer link.mu.Unlock()
+
+	if err == nil {
+		link.failures = 0
+		return summary, nil
+	}
+
+	if errors.Is(ctx.Err(), context.Canceled) { // Отмена родительского контекста не считается ошибкой провайдера
+		return "", err
+	}
+
+	link.failures++
+	logrus.Warnf("summarizer %s failed (%d in a row): %v", link.Name, link.failures, err)
+
+	if link.failures >= c.failureThreshold { // После паузы провайдер получает одну попытку, новая ошибка снова его выключает
+		link.openUntil = c.now().Add(c.cooldown)
+		logrus.Warnf("summarizer %s is skipped until %s", link.Name, link.openUntil.Format(time.RFC3339))
+	}
+
+	return "", err
+}
+
+func (l *chainLink) promptHash(ctx context.Context, prompts *Prompts) string { // Метод возвращает хэш запроса вместе с провайдером и моделью, которые строят саммари
+	return PromptHash(l.Name + "\x00" + l.Model + "\x00" + prompts.Hash(promptDataFromContext(ctx)))
+}
+
+func (l *chainLink) available(ctx context.Context, now time.Time) bool { // Метод проверяет что провайдер не выключен предохранителем и у него остался бюджет
+	l.mu.Lock()
+	open := !now.Before(l.openUntil)
+	l.mu.Unlock()
+
+	return open && !l.Budget.Exceeded(ctx) // Исчерпанный бюджет не ошибка провайдера, предохранитель не трогаем
+}
@@ -0,0 +1,183 @@
+package summary
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+type fakeSummarizer struct {
+	model string
+	reply string
+	err   error
+	calls int
+}
+
+func (s *fakeSummarizer) Summarize(ctx context.Context, text string) (string, error) {
+	s.calls++
+	return s.reply, s.err
+}
+
+func (s *fakeSummarizer) Model() string { return s.model }
+
+type fakeClock struct{ now time.Time }
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+func newTestChain(cfg ChainConfig, primary, fallback *fakeSummarizer) (*Chain, *fakeClock) {
+	clock := &fakeClock{now: time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)}
+
+	chain := NewChain(cfg,
+		ChainProvider{Name: "openai", Summarizer: primary},
+		ChainProvider{Name: "textrank", Summarizer: fallback},
+	)
+	chain.now = clock.Now
+
+	return chain, clock
+}
+
+func TestChainBreaker(t *testing.T) {
+	errTimeout := errors.New("timeout")
+
+	steps := []struct {
+		name         string
+		after        time.Duration // Сколько прошло с предыдущего запроса
+		primaryErr   error
+		wantProvider string
+		wantCalls    int // Сколько всего раз опрошен основной провайдер
+	}{
+		{name: "primary answers", wantProvider: "openai", wantCalls: 1},
+		{name: "first failure", primaryErr: errTimeout, wantProvider: "textrank", wantCalls: 2},
+		{name: "second failure opens breaker", primaryErr: errTimeout, wantProvider: "textrank", wantCalls: 3},
+		{name: "primary is skipped", after: time.Minute, wantProvider: "textrank", wantCalls: 3},
+		{name: "primary is skipped until cooldown ends", after: 3 * time.Minute, wantProvider: "textrank", wantCalls: 3},
+		{name: "one attempt after cooldown fails again", after: time.Minute, primaryErr: errTimeout, wantProvider: "textrank", wantCalls: 4},
+		{name: "breaker opens again", after: time.Second, wantProvider: "textrank", wantCalls: 4},
+		{name: "primary recovers after cooldown", after: 5 * time.Minute, wantProvider: "openai", wantCalls: 5},
+		{name: "one failure after recovery does not open breaker", primaryErr: errTimeout, wantProvider: "textrank", wantCalls: 6},
+		{name: "primary answers again", wantProvider: "openai", wantCalls: 7},
+	}
+
+	var (
+		primary  = &fakeSummarizer{model: "gpt", reply: "primary"}
+		fallback = &fakeSummarizer{reply: "fallback"}
+	)
+
+	chain, clock := newTestChain(ChainConfig{FailureThreshold: 2, Cooldown: 5 * time.Minute}, primary, fallback)
+
+	for _, step := range steps {
+		clock.now = clock.now.Add(step.after)
+		primary.err = step.primaryErr
+
+		summary, err := chain.Generate(context.Background(), "text")
+		if err != nil {
+			t.Fatalf("%s: Generate() error = %v", step.name, err)
+		}
+		if summary.Provider != step.wantProvider {
+			t.Errorf("%s: provider = %q, want %q", step.name, summary.Provider, step.wantProvider)
+		}
+		if primary.calls != step.wantCalls {
+			t.Errorf("%s: primary calls = %d, want %d", step.name, primary.calls, step.wantCalls)
+		}
+	}
+}
+
+func TestChainNoSummary(t *testing.T) {
+	tests := []struct {
+		name               string
+		postWithoutSummary bool
+		fallbackErr        error
+		wantErr            bool
+	}{
+		{name: "all providers failed", fallbackErr: errors.New("broken"), wantErr: true},
+		{name: "all providers returned empty summary", wantErr: true},
+		{name: "post without summary", postWithoutSummary: true, fallbackErr: errors.New("broken")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chain, _ := newTestChain(ChainConfig{PostWithoutSummary: tt.postWithoutSummary},
+				&fakeSummarizer{err: errors.New("unavailable")},
+				&fakeSummarizer{err: tt.fallbackErr},
+			)
+
+			summary, err := chain.Generate(context.Background(), "text")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Generate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr && !errors.Is(err, ErrNoSummary) {
+				t.Errorf("Generate() error = %v, want ErrNoSummary", err)
+			}
+			if summary.Text != "" || !summary.Created.IsZero() {
+				t.Errorf("Generate() = %+v, want empty summary", summary)
+			}
+		})
+	}
+}
+
+func TestChainDisabled(t *testing.T) {
+	fallback := &fakeSummarizer{reply: "fallback"}
+
+	chain := NewChain(ChainConfig{}, // Саммари обязательны, но none их выключает и это не ошибка
+		ChainProvider{Name: "none", Summarizer: Disabled{}},
+		ChainProvider{Name: "textrank", Summarizer: fallback},
+	)
+
+	summary, err := chain.Generate(context.Background(), "text")
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if summary.Text != "" || !summary.Created.IsZero() {
+		t.Errorf("Generate() = %+v, want empty summary", summary)
+	}
+	if fallback.calls != 0 {
+		t.Errorf("fallback called %d times, want 0", fallback.calls)
+	}
+}
+
+func TestChainPromptHash(t *testing.T) {
+	var (
+		ctx      = WithPromptData(context.Background(), PromptData{Title: "Go 1.24", Source: "Go Blog"})
+		primary  = &fakeSummarizer{model: "gpt", reply: "primary"}
+		fallback = &fakeSummarizer{reply: "fallback"}
+	)
+
+	chain, clock := newTestChain(ChainConfig{FailureThreshold: 2, Cooldown: 5 * time.Minute}, primary, fallback)
+
+	summary, err := chain.Generate(ctx, "text")
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if summary.PromptHash != chain.PromptHash(ctx) {
+		t.Errorf("primary summary hash %q differs from chain hash %q", summary.PromptHash, chain.PromptHash(ctx))
+	}
+
+	primary.err = errors.New("unavailable")
+
+	summary, err = chain.Generate(ctx, "text")
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if summary.Provider != "textrank" || summary.PromptHash == chain.PromptHash(ctx) { // Основной провайдер еще доступен, саммари будет построено заново
+		t.Errorf("fallback summary %+v matches chain hash %q while primary is available", summary, chain.PromptHash(ctx))
+	}
+
+	summary, err = chain.Generate(ctx, "text")
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if summary.Provider != "textrank" || summary.PromptHash != chain.PromptHash(ctx) { // Предохранитель открыт, саммари запасного провайдера используется повторно
+		t.Errorf("fallback summary %+v differs from chain hash %q while primary is skipped", summary, chain.PromptHash(ctx))
+	}
+
+	clock.now = clock.now.Add(5 * time.Minute)
+	if summary.PromptHash == chain.PromptHash(ctx) {
+		t.Error("fallback summary matches chain hash after primary cooldown")
+	}
+
+	other, _ := newTestChain(ChainConfig{}, &fakeSummarizer{model: "gpt-4o", reply: "primary"}, fallback)
+	if other.PromptHash(ctx) == chain.PromptHash(ctx) {
+		t.Error("chains with different models have the same prompt hash")
+	}
+}
@@ -0,0 +1,85 @@
+package summary
+
+import (
+	"strings"
+	"unicode"
+)
+
+const runesPerToken = 3 // Обратная оценка к estimateTokens
+
+func truncateTokens(text string, budget int) string { // Функция обрезает текст до budget токенов по границе предложения, если она есть в последней четверти текста
+	limit := (budget - 1) * runesPerToken
+	runes := []rune(text)
+
+	if budget <= 0 || len(runes) <= limit {
+		return text
+	}
+
+	cut := runes[:limit]
+
+	for i := len(cut) - 1; i >= limit*3/4; i-- { // Ищем конец предложения, чтобы модель не получила оборванную мысль
+		if strings.ContainsRune(".!?…\n", cut[i]) {
+			return strings.TrimSpace(string(cut[:i+1]))
+		}
+	}
+
+	for i := len(cut) - 1; i >= limit*3/4; i-- { // Иначе хотя бы не режем слово
+		if unicode.IsSpace(cut[i]) {
+			return strings.TrimSpace(string(cut[:i]))
+		}
+	}
+
+	return string(cut)
+}
+
+func splitChunks(text string, budget int) []string { // Функция делит текст на части не больше budget токенов по абзацам, длинные абзацы делятся по предложениям
+	var (
+		chunks  []string
+		current strings.Builder
+	)
+
+	budget = max(budget, 2) // В бюджет в один токен truncateTokens не помещает ни одного символа и длинное предложение резалось бы бесконечно
+
+	flush := func() {
+		if chunk := strings.TrimSpace(current.String()); chunk != "" {
+			chunks = append(chunks, chunk)
+		}
+		current.Reset()
+	}
+
+	add := func(part, separator string) {
+		if current.Len() > 0 && estimateTokens(current.String()+separator+part) > budget {
+			flush()
+		}
+		if current.Len() > 0 {
+			current.WriteString(separator)
+		}
+		current.WriteString(part)
+	}
+
+	for _, paragraph := range strings.Split(text, "\n") {
+		paragraph = strings.TrimSpace(paragraph)
+		if paragraph == "" {
+			continue
+		}
+
+		if estimateTokens(paragraph) <= budget {
+			add(paragraph, "\n")
+			continue
+		}
+
+		for _, sentence := range splitSentences(paragraph) {
+			for estimateTokens(sentence) > budget { // Предложение длиннее бюджета режется как есть
+				head := truncateTokens(sentence, budget)
+				add(head, " ")
+				flush()
+				sentence = strings.TrimSpace(strings.TrimPrefix(sentence, head))
+			}
+			add(sentence, " ")
+		}
+	}
+
+	flush()
+
+	return chunks
+}
@@ -0,0 +1,76 @@
+package summary
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestTruncateTokens(t *testing.T) {
+	tests := []struct {
+		name   string
+		text   string
+		budget int
+		want   string
+	}{
+		{name: "fits", text: "Go 1.24 вышел.", budget: 10, want: "Go 1.24 вышел."},
+		{name: "no budget", text: strings.Repeat("a", 100), budget: 0, want: strings.Repeat("a", 100)},
+		{name: "cut on sentence boundary", text: "Первое предложение тут такое. Второе предложение длинное и не влезает", budget: 11, want: "Первое предложение тут такое."},
+		{name: "cut on word without sentence boundary", text: "alpha beta gamma delta epsilon zeta eta theta", budget: 11, want: "alpha beta gamma delta"},
+		{name: "cut inside word without spaces", text: strings.Repeat("a", 40), budget: 11, want: strings.Repeat("a", 30)},
+		{name: "boundary outside last quarter is ignored", text: "Go. alpha beta gamma delta epsilon zeta eta", budget: 11, want: "Go. alpha beta gamma delta"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := truncateTokens(tt.text, tt.budget)
+			if got != tt.want {
+				t.Errorf("truncateTokens(%q, %d) = %q, want %q", tt.text, tt.budget, got, tt.want)
+			}
+			if tt.budget > 0 && estimateTokens(got) > tt.budget {
+				t.Errorf("truncateTokens(%q, %d) = %d tokens, over budget", tt.text, tt.budget, estimateTokens(got))
+			}
+		})
+	}
+}
+
+func TestSplitChunks(t *testing.T) {
+	tests := []struct {
+		name   string
+		text   string
+		budget int
+		want   []string
+	}{
+		{name: "empty", text: "\n\n", budget: 10, want: nil},
+		{name: "paragraphs are joined", text: "First paragraph.\n\nSecond paragraph.", budget: 100, want: []string{"First paragraph.\nSecond paragraph."}},
+		{name: "paragraphs over budget are split", text: "First paragraph.\nSecond paragraph.", budget: 10, want: []string{"First paragraph.", "Second paragraph."}},
+		{
+			name:   "long paragraph is split by sentences",
+			text:   "One sentence here. Two sentence here. Three sentence here.",
+			budget: 10,
+			want:   []string{"One sentence here.", "Two sentence here.", "Three sentence here."},
+		},
+		{
+			name:   "sentence over budget is cut by words",
+			text:   "alpha beta gamma delta epsilon zeta eta theta iota kappa",
+			budget: 11,
+			want:   []string{"alpha beta gamma delta", "epsilon zeta eta theta iota", "kappa"},
+		},
+		{name: "budget of one token", text: "alpha beta", budget: 1, want: []string{"alp", "ha", "beta"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := splitChunks(tt.text, tt.budget)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("splitChunks(%q, %d) = %q, want %q", tt.text, tt.budget, got, tt.want)
+			}
+
+			for _, chunk := range got {
+				if tokens := estimateTokens(chunk); tokens > max(tt.budget, 2) {
+					t.Errorf("chunk %q has %d tokens, budget %d", chunk, tokens, tt.budget)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,10 @@
+package summary
+
+func (s *OpenAISummarizer) AvailableTokens() int { // Остаток бюджета токенов минуты, для тестов с summarytest.Server
+	s.limiter.mu.Lock()
+	defer s.limiter.mu.Unlock()
+
+	s.limiter.refill()
+
+	return int(s.limiter.available)
+}
@@ -0,0 +1,81 @@
+package summary
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+type tokenLimiter struct { // Ограничение расхода токенов в минуту, токены восстанавливаются равномерно в течение минуты
+	mu        sync.Mutex
+	perMinute float64
+	available float64
+	last      time.Time
+	now       func() time.Time
+}
+
+func newTokenLimiter(perMinute int) *tokenLimiter { // Конструктор для структуры tokenLimiter, 0 - без ограничения
+	if perMinute <= 0 {
+		return nil
+	}
+
+	return &tokenLimiter{
+		perMinute: float64(perMinute),
+		available: float64(perMinute),
+		last:      time.Now(),
+		now:       time.Now,
+	}
+}
+
+func (l *tokenLimiter) Wait(ctx context.Context, tokens int) error { // Метод ждет пока в бюджете минуты появится tokens токенов и резервирует их
+	if l == nil {
+		return nil
+	}
+
+	for {
+		l.mu.Lock()
+		l.refill()
+
+		need := math.Min(float64(tokens), l.perMinute) // Запрос больше минутного бюджета ждет полного бюджета, иначе он не выполнится никогда
+		if l.available >= need {
+			l.available -= float64(tokens)
+			l.mu.Unlock()
+			return nil
+		}
+
+		wait := time.Duration((need - l.available) / l.perMinute * float64(time.Minute))
+		l.mu.Unlock()
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+	}
+}
+
+func (l *tokenLimiter) Refund(tokens int) { // Метод возвращает в бюджет зарезервированные, но не потраченные токены, отрицательное значение списывает перерасход
+	if l == nil {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.refill()
+	l.available = math.Min(l.available+float64(tokens), l.perMinute)
+}
+
+func (l *tokenLimiter) refill() { // Метод начисляет токены за прошедшее время, вызывается под мьютексом
+	now := l.now()
+	l.available = math.Min(l.available+now.Sub(l.last).Minutes()*l.perMinute, l.perMinute)
+	l.last = now
+}
+
+func estimateTokens(text string) int { // Функция грубо оценивает количество токенов в тексте, для русского текста токен в среднем короче чем для английского
+	return utf8.RuneCountInString(text)/3 + 1
+}
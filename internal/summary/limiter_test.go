@@ -0,0 +1,87 @@
+package summary
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestTokenLimiter(t *testing.T) {
+	tests := []struct {
+		name   string
+		wait   []int         // Резервы по порядку, все должны пройти без ожидания
+		refund int           // Сколько вернуть после резервов
+		after  time.Duration // Сколько прошло после возврата
+		want   int           // Сколько токенов доступно в итоге
+	}{
+		{name: "reserve", wait: []int{300, 200}, want: 500},
+		{name: "refund unused", wait: []int{600}, refund: 400, want: 800},
+		{name: "overspend", wait: []int{300}, refund: -200, want: 500},
+		{name: "refund does not exceed budget", wait: []int{100}, refund: 500, want: 1000},
+		{name: "refill over time", wait: []int{1000}, after: 30 * time.Second, want: 500},
+		{name: "refill does not exceed budget", wait: []int{100}, after: time.Hour, want: 1000},
+		{name: "large request takes whole budget", wait: []int{1500}, want: -500},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				clock   = &fakeClock{now: time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)}
+				limiter = newTokenLimiter(1000)
+			)
+			limiter.now, limiter.last = clock.Now, clock.now
+
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+
+			for _, tokens := range tt.wait {
+				if err := limiter.Wait(ctx, tokens); err != nil {
+					t.Fatalf("Wait(%d) error = %v", tokens, err)
+				}
+			}
+
+			limiter.Refund(tt.refund)
+			clock.now = clock.now.Add(tt.after)
+			limiter.refill()
+
+			if got := int(limiter.available); got != tt.want {
+				t.Errorf("available = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTokenLimiterWait(t *testing.T) {
+	limiter := newTokenLimiter(60_000) // Тысяча токенов в секунду
+	if err := limiter.Wait(context.Background(), 60_000); err != nil {
+		t.Fatalf("Wait() error = %v", err)
+	}
+
+	start := time.Now()
+	if err := limiter.Wait(context.Background(), 100); err != nil { // Ждет пока начислится 100 токенов
+		t.Fatalf("Wait() error = %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+		t.Errorf("Wait() returned after %s, want about 100ms", elapsed)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	if err := limiter.Wait(ctx, 60_000); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Wait() error = %v, want context.DeadlineExceeded", err)
+	}
+}
+
+func TestNilTokenLimiter(t *testing.T) {
+	limiter := newTokenLimiter(0)
+	if limiter != nil {
+		t.Fatal("newTokenLimiter(0) is not nil")
+	}
+
+	if err := limiter.Wait(context.Background(), 1_000_000); err != nil {
+		t.Errorf("Wait() error = %v", err)
+	}
+	limiter.Refund(100)
+}
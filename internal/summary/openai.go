@@ -2,71 +2,298 @@ package summary
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/sashabaranov/go-openai"
 	"github.com/sirupsen/logrus"
+	"github.com/speeddem0n/GoNewsBot/internal/models"
 )
 
-type OpenAISummarizer struct { // Структура для работы с chatGpt
-	client  *openai.Client
-	prompt  string
-	enabled bool
-	mu      sync.Mutex
+const (
+	defaultMaxTokens   = 256 // Длина саммари если MaxTokens не задан
+	defaultConcurrency = 2   // Сколько запросов к API выполняется одновременно если Concurrency не задан
+	defaultMaxChunks   = 8   // Сколько частей длинной статьи обрабатывается в режиме map-reduce если MaxChunks не задан
+	minInputTokens     = 64  // Меньший бюджет на текст статьи не оставляет модели контекста
+)
+
+var openAICompatible = map[string]ProviderConfig{ // OpenAI-совместимые провайдеры и их настройки по умолчанию
+	"openai": {
+		BaseURL: "https://api.openai.com/v1",
+		Model:   openai.GPT3Dot5Turbo,
+	},
+	"ollama": { // Ollama отдает OpenAI-совместимое API по адресу /v1
+		BaseURL: "http://localhost:11434/v1",
+		Model:   "llama3.1",
+	},
+	"llamacpp": { // llama-server из llama.cpp, модель задается при запуске сервера и в запросе игнорируется
+		BaseURL: "http://localhost:8080/v1",
+		Model:   "local",
+	},
+}
+
+func init() {
+	for name, defaults := range openAICompatible {
+		Register(name, newOpenAICompatible(name, defaults))
+	}
+}
+
+func newOpenAICompatible(name string, defaults ProviderConfig) Factory { // Функция создает фабрику OpenAI-совместимого провайдера с настройками по умолчанию
+	return func(cfg ProviderConfig) (Summarizer, error) {
+		if name == "openai" && cfg.APIKey == "" { // Без ключа OpenAI не ответит, ошибка конфига видна при запуске, а не в каждом запросе
+			return nil, errors.New("openai provider requires an api key, set it or choose another provider, for example textrank")
+		}
+
+		if cfg.BaseURL == "" {
+			cfg.BaseURL = defaults.BaseURL
+		}
+		if cfg.Model == "" {
+			cfg.Model = defaults.Model
+		}
+		if cfg.MaxTokens == 0 {
+			cfg.MaxTokens = defaultMaxTokens
+		}
+		cfg.Provider = name
+
+		logrus.Infof("%s summarizer enabled: model %s, base url %s", name, cfg.Model, cfg.BaseURL)
+
+		return NewOpenAISummarizer(cfg), nil
+	}
+}
+
+type OpenAISummarizer struct { // Структура для работы с OpenAI и OpenAI-совместимыми API
+	client      *openai.Client
+	provider    string
+	model       string
+	prompts     *Prompts
+	temperature float32
+	maxTokens   int
+	sem         chan struct{} // Семафор ограничивает количество одновременных запросов к API
+	limiter     *tokenLimiter // Бюджет токенов в минуту, nil - без ограничения
+	inputTokens int           // Бюджет на текст статьи в одном запросе, 0 - без ограничения
+	mapReduce   bool          // Длинная статья делится на части вместо обрезки
+	maxChunks   int
+	usage       UsageRecorder // Хранилище расхода токенов, nil - расход не записывается
+	pricing     Pricing
+}
+
+func NewOpenAISummarizer(cfg ProviderConfig) *OpenAISummarizer { // Конструктор для структуры OpenAISummarizer
+	clientConfig := openai.DefaultConfig(cfg.APIKey)
+	if cfg.BaseURL != "" {
+		clientConfig.BaseURL = strings.TrimSuffix(cfg.BaseURL, "/")
+	}
+
+	temperature := cfg.Temperature
+	if temperature == 0 { // go-openai не отправляет нулевую температуру и сервер подставляет свою, поэтому передаем минимальное ненулевое значение
+		temperature = math.SmallestNonzeroFloat32
+	}
+
+	concurrency := cfg.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultConcurrency
+	}
+
+	inputTokens := cfg.InputTokens
+	if inputTokens > 0 && inputTokens < minInputTokens {
+		inputTokens = minInputTokens
+	}
+
+	maxChunks := cfg.MaxChunks
+	if maxChunks <= 0 {
+		maxChunks = defaultMaxChunks
+	}
+
+	return &OpenAISummarizer{
+		client:      openai.NewClientWithConfig(clientConfig), // Клиент OpenAI API, для локальных серверов меняется только адрес
+		provider:    cfg.Provider,
+		model:       cfg.Model,
+		prompts:     cfg.Prompts,
+		temperature: temperature,
+		maxTokens:   cfg.MaxTokens,
+		sem:         make(chan struct{}, concurrency),
+		limiter:     newTokenLimiter(cfg.TokensPerMinute),
+		inputTokens: inputTokens,
+		mapReduce:   cfg.MapReduce,
+		maxChunks:   maxChunks,
+		usage:       cfg.Usage,
+		pricing:     cfg.Pricing,
+	}
+}
+
+func (s *OpenAISummarizer) Model() string { // Метод возвращает модель которая генерирует саммари
+	return s.model
+}
+
+func (s *OpenAISummarizer) Summarize(ctx context.Context, text string) (string, error) { // Метод Summarizе для получения саммари из модели, длинные статьи обрезаются или обрабатываются по частям
+	if s.inputTokens == 0 || estimateTokens(text) <= s.inputTokens {
+		return s.complete(ctx, text)
+	}
+
+	if !s.mapReduce {
+		return s.complete(ctx, truncateTokens(text, s.inputTokens))
+	}
+
+	return s.summarizeChunks(ctx, text)
+}
+
+func (s *OpenAISummarizer) summarizeChunks(ctx context.Context, text string) (string, error) { // Метод строит саммари каждой части статьи, а затем саммари из саммари частей
+	chunks := splitChunks(text, s.inputTokens)
+	if len(chunks) > s.maxChunks { // Очень длинная статья: конец отбрасывается, чтобы не тратить бюджет токенов
+		logrus.Warnf("article has %d chunks, only first %d are summarized", len(chunks), s.maxChunks)
+		chunks = chunks[:s.maxChunks]
+	}
+
+	var (
+		wg        sync.WaitGroup
+		errOnce   sync.Once
+		firstErr  error
+		summaries = make([]string, len(chunks))
+	)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	for i, chunk := range chunks { // Части обрабатываются параллельно, количество одновременных запросов ограничивает семафор
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			summary, err := s.complete(ctx, chunk)
+			if err != nil {
+				errOnce.Do(func() {
+					firstErr = err
+					cancel() // Без одной из частей саммари будет неполным, остальные запросы не нужны
+				})
+				return
+			}
+
+			summaries[i] = summary
+		}()
+	}
+
+	wg.Wait()
+
+	if firstErr != nil {
+		return "", firstErr
+	}
+
+	return s.complete(ctx, truncateTokens(strings.Join(summaries, "\n\n"), s.inputTokens))
 }
 
-func NewOpenAISummarizer(apiKey string, prompt string) *OpenAISummarizer {
-	s := &OpenAISummarizer{
-		client: openai.NewClient(apiKey), // NewClient создает новый OpenAI API клиент.
-		prompt: prompt,
+func (s *OpenAISummarizer) complete(ctx context.Context, text string) (string, error) { // Метод отправляет запрос к модели и один раз повторяет его со строгой инструкцией если ответ непригоден
+	instruction, err := s.prompts.Render(promptDataFromContext(ctx)) // Инструкция для модели из шаблона источника или канала
+	if err != nil {
+		return "", fmt.Errorf("render prompt: %w", err)
+	}
+
+	summary, err := s.attempt(ctx, instruction, text)
+	if !errors.Is(err, ErrUnusableSummary) && !errors.Is(err, errNoChoices) {
+		return summary, err
 	}
 
-	logrus.Infof("openai summarizer enabled: %v", apiKey != "") // Логируем включение summarizer
+	logrus.Warnf("%s, retrying with stricter prompt", err)
+
+	return s.attempt(ctx, instruction+strictPromptSuffix, text)
+}
 
-	if apiKey != "" { // Если apiKey не пустой то Summarizer включен
-		s.enabled = true
+func (s *OpenAISummarizer) attempt(ctx context.Context, instruction, text string) (string, error) { // Метод запрашивает саммари и проверяет ответ модели
+	rawSummary, err := s.request(ctx, instruction, text, s.maxTokens)
+	if err != nil {
+		return "", err
 	}
 
-	return s
+	return postprocessSummary(rawSummary)
 }
 
-func (s *OpenAISummarizer) Summarize(ctx context.Context, text string) (string, error) { // Метод Summarizе для получения саммари из chatGPT
-	s.mu.Lock() // Блокируемся мьютексом так так библиотека не потокобезопасна
-	defer s.mu.Unlock()
+func (s *OpenAISummarizer) Translate(ctx context.Context, text, language string) (string, error) { // Метод переводит короткий текст, например заголовок, на язык с кодом language
+	name, ok := languageNames[language]
+	if !ok {
+		return "", fmt.Errorf("unsupported language %q", language)
+	}
 
-	if !s.enabled { // Если Summarizer выключен то возвращаем пустое Summary
-		logrus.Info("Summarizer is disabled, can't generate summary")
-		return "", nil
+	translation, err := s.request(ctx, fmt.Sprintf(translatePrompt, name), text, estimateTokens(text)*2+16) // Перевод может быть длиннее оригинала
+	if err != nil {
+		return "", err
+	}
+
+	return cleanReply(translation), nil
+}
+
+func (s *OpenAISummarizer) request(ctx context.Context, instruction, text string, maxTokens int) (string, error) { // Метод отправляет модели инструкцию системным сообщением и текст сообщением пользователя
+	reserved := estimateTokens(instruction) + estimateTokens(text) + maxTokens // Ответ может занять все maxTokens, лишнее вернется в бюджет после ответа
+
+	if err := s.limiter.Wait(ctx, reserved); err != nil {
+		return "", err
+	}
+
+	select { // Ждем свободного места под запрос, клиент go-openai потокобезопасен и сам запрос можно не сериализовать
+	case s.sem <- struct{}{}:
+		defer func() { <-s.sem }()
+	case <-ctx.Done():
+		s.limiter.Refund(reserved)
+		return "", ctx.Err()
 	}
 
-	request := openai.ChatCompletionRequest{ // Создаем запрос к chatGPT
-		Model: "gpt-3.5-turbo", // Модеть chatGPT
+	request := openai.ChatCompletionRequest{ // Создаем запрос к модели
+		Model: s.model,
 		Messages: []openai.ChatCompletionMessage{ // Слайс передоваемых сообщений
 			{
 				Role:    openai.ChatMessageRoleSystem,
-				Content: fmt.Sprintf("%s%s", text, s.prompt), // Передаем сам текст и просим сделать для него summary
+				Content: instruction, // Инструкция отдельно от статьи, так текст статьи не может ее переопределить
+			},
+			{
+				Role:    openai.ChatMessageRoleUser,
+				Content: text, // Текст статьи
 			},
 		},
-		MaxTokens:   256,
-		Temperature: 0.7,
+		MaxTokens:   maxTokens,
+		Temperature: s.temperature,
 		TopP:        1,
 	}
 
 	resp, err := s.client.CreateChatCompletion(ctx, request) // Вызов API для создания завершения сообщения чата.
 	if err != nil {
+		s.limiter.Refund(reserved) // Неудачный запрос не расходует токены, иначе серия ошибок исчерпала бы бюджет минуты
 		logrus.Errorf("Failed to to Create a completion for the chat message: %s", err)
 		return "", err
 	}
 
-	rawSammary := strings.TrimSpace(resp.Choices[0].Message.Content) // Open ai вернет несколько вариантов, берем самый первый и избавляемся от лишних пробелов
+	if resp.Usage.TotalTokens > 0 { // Бюджет уточняется по фактическому расходу из ответа
+		s.limiter.Refund(reserved - resp.Usage.TotalTokens)
+	} else { // Сервер не сообщил расход, как часто делают локальные серверы, резерв не должен копиться
+		s.limiter.Refund(reserved)
+	}
 
-	if strings.HasSuffix(rawSammary, ".") { // Проверяем сгененрировал ди chatGPT точку в конце статьи
-		return rawSammary, nil
+	s.recordUsage(ctx, resp.Usage)
+
+	if len(resp.Choices) == 0 { // Некоторые OpenAI-совместимые серверы при ошибке фильтра контента возвращают пустой список
+		return "", errNoChoices
 	}
 
-	sentences := strings.Split(rawSammary, ".") // В ином случае разбиваем rawSammary на отдельные предложения
+	return strings.TrimSpace(resp.Choices[0].Message.Content), nil // Модель может вернуть несколько вариантов, берем самый первый и избавляемся от лишних пробелов
+}
 
-	return strings.Join(sentences[:len(sentences)-1], ".") + ".", nil // И джойним все предложения через точку и добавляем точку в конце
+func (s *OpenAISummarizer) recordUsage(ctx context.Context, usage openai.Usage) { // Метод записывает расход токенов запроса для статьи и канала из контекста
+	if s.usage == nil || usage.TotalTokens == 0 {
+		return
+	}
+
+	data := promptDataFromContext(ctx)
+
+	if err := s.usage.Record(context.WithoutCancel(ctx), models.SummaryUsage{ // Токены уже потрачены, поэтому расход записывается даже если запрос статьи отменен
+		SourceID:         data.SourceID,
+		ChatID:           data.ChatID,
+		Provider:         s.provider,
+		Model:            s.model,
+		PromptTokens:     usage.PromptTokens,
+		CompletionTokens: usage.CompletionTokens,
+		Cost:             s.pricing.Cost(usage.PromptTokens, usage.CompletionTokens),
+		Created:          time.Now(),
+	}); err != nil {
+		logrus.Errorf("failed to record summarizer usage: %v", err)
+	}
 }
@@ -0,0 +1,68 @@
+package summary_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sashabaranov/go-openai"
+
+	"github.com/speeddem0n/GoNewsBot/internal/summary"
+	"github.com/speeddem0n/GoNewsBot/internal/summary/summarytest"
+)
+
+func TestOpenAISummarizerTokenBudget(t *testing.T) {
+	const perMinute = 10_000
+
+	tests := []struct {
+		name     string
+		reply    string
+		err      error // Ошибка API на каждый запрос
+		wantErr  bool
+		maxSpent int // Сколько токенов может уйти из бюджета за все запросы
+	}{
+		{
+			name:     "unused reservation is refunded",
+			reply:    "Go 1.24 вышел с новыми итераторами.",
+			maxSpent: 1_000, // summarytest.Server считает токены по словам, каждый запрос тратит несколько десятков
+		},
+		{
+			name:     "api error refunds reservation",
+			err:      errors.New("overloaded"),
+			wantErr:  true,
+			maxSpent: 1, // Погрешность пополнения бюджета за время теста
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := summarytest.NewServer()
+			defer server.Close()
+			server.SetReplyFunc(func(openai.ChatCompletionRequest) (string, error) { return tt.reply, tt.err })
+
+			cfg := server.Config("openai")
+			cfg.MaxTokens = 2_000
+			cfg.TokensPerMinute = perMinute
+
+			summarizer := summary.NewOpenAISummarizer(cfg)
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			for range 10 { // Каждый запрос резервирует больше 2000 токенов, без возврата резерва бюджет минуты кончился бы на пятом
+				_, err := summarizer.Summarize(ctx, "Вышел Go 1.24. В нем появились итераторы и псевдонимы дженерик типов.")
+				if (err != nil) != tt.wantErr {
+					t.Fatalf("Summarize() error = %v, wantErr %v", err, tt.wantErr)
+				}
+			}
+
+			if got := summarizer.AvailableTokens(); got < perMinute-tt.maxSpent {
+				t.Errorf("available tokens = %d, want at least %d", got, perMinute-tt.maxSpent)
+			}
+			if got := len(server.Requests()); got != 10 {
+				t.Errorf("server got %d requests, want 10", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package summary
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+const strictPromptSuffix = "\n\nYour previous reply could not be used. Reply with the summary text only: complete sentences of plain prose, without introductions, lists, markdown or refusals." // Дополнение инструкции для повторного запроса после неудачного ответа
+
+const (
+	minSummaryLetters = 10  // Ответ с меньшим количеством букв не считается саммари
+	minRepeatWords    = 12  // С какого количества слов проверяется повтор одних и тех же слов
+	minUniqueRatio    = 0.3 // Доля разных слов, ниже которой ответ считается зациклившимся
+)
+
+var (
+	ErrUnusableSummary = errors.New("summarizer returned unusable summary") // Ошибка когда ответ модели не получилось превратить в саммари
+	errNoChoices       = errors.New("summarizer returned no choices")       // Ошибка когда API вернул ответ без вариантов
+)
+
+var replyLabels = []string{"summary:", "саммари:", "краткое содержание:", "кратко:", "резюме:", "выжимка:", "tl;dr:", "tldr:"} // Подписи которые модели добавляют перед ответом
+
+var refusalPrefixes = []string{"i'm sorry", "i am sorry", "sorry,", "i cannot", "i can't", "as an ai", "извините", "простите", "к сожалению, я не могу", "я не могу", "как языковая модель", "как ии"} // Начала отказов модели
+
+func postprocessSummary(text string) (string, error) { // Функция чистит ответ модели, проверяет что он похож на саммари и обрезает оборванное последнее предложение
+	text = cleanReply(text)
+
+	if err := checkSummary(text); err != nil {
+		return "", err
+	}
+
+	return trimIncomplete(text), nil
+}
+
+func cleanReply(text string) string { // Функция убирает из ответа модели разметку, подписи и кавычки вокруг всего текста
+	text = strings.TrimSpace(text)
+	text = strings.TrimSpace(strings.TrimSuffix(strings.TrimPrefix(text, "```"), "```")) // Некоторые модели оборачивают ответ в блок кода
+	text = strings.ReplaceAll(text, "**", "")
+
+	for _, label := range replyLabels {
+		if len(text) >= len(label) && strings.EqualFold(text[:len(label)], label) {
+			text = strings.TrimSpace(text[len(label):])
+			break
+		}
+	}
+
+	for _, quotes := range [][2]string{{`"`, `"`}, {"«", "»"}, {"“", "”"}} {
+		if strings.HasPrefix(text, quotes[0]) && strings.HasSuffix(text, quotes[1]) && len(text) > len(quotes[0])+len(quotes[1]) &&
+			!strings.Contains(text[len(quotes[0]):len(text)-len(quotes[1])], quotes[1]) { // Кавычки внутри текста значат что снаружи не обертка, а цитата
+			text = strings.TrimSpace(text[len(quotes[0]) : len(text)-len(quotes[1])])
+		}
+	}
+
+	return text
+}
+
+func checkSummary(text string) error { // Функция проверяет что ответ модели не пустой, не отказ и не мусор
+	var letters int
+	for _, r := range text {
+		if unicode.IsLetter(r) {
+			letters++
+		}
+	}
+
+	if letters < minSummaryLetters {
+		return fmt.Errorf("%w: too short: %q", ErrUnusableSummary, text)
+	}
+
+	if strings.ContainsRune(text, unicode.ReplacementChar) { // Битая кодировка в ответе локальной модели
+		return fmt.Errorf("%w: invalid characters", ErrUnusableSummary)
+	}
+
+	lower := strings.ToLower(text)
+	for _, prefix := range refusalPrefixes {
+		if strings.HasPrefix(lower, prefix) {
+			return fmt.Errorf("%w: refusal: %q", ErrUnusableSummary, text)
+		}
+	}
+
+	words := strings.FieldsFunc(lower, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) })
+	if len(words) >= minRepeatWords {
+		unique := make(map[string]struct{}, len(words))
+		for _, word := range words {
+			unique[word] = struct{}{}
+		}
+
+		if float64(len(unique))/float64(len(words)) < minUniqueRatio { // Модель зациклилась и повторяет одни и те же слова
+			return fmt.Errorf("%w: repeated words", ErrUnusableSummary)
+		}
+	}
+
+	return nil
+}
+
+func trimIncomplete(text string) string { // Функция отбрасывает последнее предложение если модель оборвала его на лимите токенов, переносы строк в остальном тексте сохраняются
+	runes := []rune(text)
+
+	bounds := sentenceBounds(runes) // Сокращения, инициалы и дробные числа не считаются концом предложения
+	if len(bounds) < 2 || sentenceComplete(strings.TrimSpace(string(runes[bounds[len(bounds)-1][0]:]))) {
+		return text // Единственное предложение оставляем как есть, даже без точки
+	}
+
+	return strings.TrimSpace(string(runes[:bounds[len(bounds)-2][1]])) // Обрезаем исходный текст по концу последнего законченного предложения
+}
+
+func sentenceComplete(sentence string) bool { // Функция проверяет что предложение заканчивается знаком конца предложения, кавычки и скобки после него не учитываются
+	sentence = strings.TrimRight(sentence, "\"'»”)")
+	if sentence == "" {
+		return false
+	}
+
+	return strings.ContainsRune(".!?…", []rune(sentence)[len([]rune(sentence))-1])
+}
@@ -0,0 +1,68 @@
+package summary
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestTrimIncomplete(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{name: "complete", text: "Go 1.24 вышел. В нем новые итераторы.", want: "Go 1.24 вышел. В нем новые итераторы."},
+		{name: "cut on token limit", text: "Go 1.24 вышел. В нем новые итераторы и", want: "Go 1.24 вышел."},
+		{name: "single sentence without dot", text: "Go 1.24 вышел с новыми итераторами", want: "Go 1.24 вышел с новыми итераторами"},
+		{name: "quote after dot", text: "Команда сказала «релиз готов.» Следующий", want: "Команда сказала «релиз готов.»"},
+		{name: "abbreviation is not an end", text: "Выручка выросла на 5 млн. руб. за год и", want: "Выручка выросла на 5 млн. руб. за год и"},
+		{name: "newlines are kept", text: "Первый пункт.\nВторой пункт.\n\nТретий абзац.", want: "Первый пункт.\nВторой пункт.\n\nТретий абзац."},
+		{name: "newlines are kept when cut", text: "Первый пункт.\nВторой пункт.\n\nТретий абзац без", want: "Первый пункт.\nВторой пункт."},
+		{name: "spaces inside sentences are kept", text: "Go  1.24   вышел.  Дальше", want: "Go  1.24   вышел."},
+		{name: "paragraph without dot is kept", text: "Главное\n\nGo 1.24 вышел.\n\nПодробнее в", want: "Главное\n\nGo 1.24 вышел."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := trimIncomplete(tt.text); got != tt.want {
+				t.Errorf("trimIncomplete(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPostprocessSummary(t *testing.T) {
+	tests := []struct {
+		name    string
+		reply   string
+		want    string
+		wantErr bool
+	}{
+		{name: "plain", reply: "Go 1.24 вышел с итераторами.", want: "Go 1.24 вышел с итераторами."},
+		{name: "label and markdown", reply: "**Summary:** Go 1.24 is out with iterators.", want: "Go 1.24 is out with iterators."},
+		{name: "code block", reply: "```\nGo 1.24 is out with iterators.\n```", want: "Go 1.24 is out with iterators."},
+		{name: "wrapping quotes", reply: "«Go 1.24 вышел с итераторами.»", want: "Go 1.24 вышел с итераторами."},
+		{name: "inner quotes are kept", reply: "«Go» вышел, сказал «автор»", want: "«Go» вышел, сказал «автор»"},
+		{name: "cut sentence", reply: "Go 1.24 вышел.\nВ нем новые", want: "Go 1.24 вышел."},
+		{name: "too short", reply: "Ок.", wantErr: true},
+		{name: "refusal", reply: "I'm sorry, I can't summarize this article.", wantErr: true},
+		{name: "russian refusal", reply: "К сожалению, я не могу открыть ссылку.", wantErr: true},
+		{name: "repeated words", reply: "go go go go go go go go go go go go go go", wantErr: true},
+		{name: "broken encoding", reply: "Go 1.24 � вышел с итераторами.", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := postprocessSummary(tt.reply)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("postprocessSummary(%q) error = %v, wantErr %v", tt.reply, err, tt.wantErr)
+			}
+			if tt.wantErr && !errors.Is(err, ErrUnusableSummary) {
+				t.Errorf("postprocessSummary(%q) error = %v, want ErrUnusableSummary", tt.reply, err)
+			}
+			if got != tt.want {
+				t.Errorf("postprocessSummary(%q) = %q, want %q", tt.reply, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,195 @@
+package summary
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+const DefaultPromptTemplate = `You are a news editor. Summarize the article{{if .Title}} "{{.Title}}"{{end}}{{if .Source}} from {{.Source}}{{end}} in {{.Length}} sentences in {{.Language}}. Reply with the summary only, without introductions or lists.` // Шаблон по умолчанию
+
+const defaultPromptName = "default" // Имя шаблона который используется если для источника и канала шаблон не выбран
+
+var languageNames = map[string]string{ // Названия языков для шаблонов, модели лучше понимают название чем код
+	"ru": "Russian",
+	"en": "English",
+}
+
+type PromptData struct { // Данные статьи для шаблона запроса
+	Title    string
+	Source   string // Название источника
+	SourceID int64
+	ChatID   int64  // Канал в который публикуется статья
+	Language string // Код языка саммари, например ru
+	Length   int    // Сколько предложений должно быть в саммари, 0 - значение из PromptConfig
+}
+
+type promptVars struct { // Переменные доступные в шаблоне
+	Title        string
+	Source       string
+	Language     string // Название языка, например Russian
+	LanguageCode string // Код языка, например ru
+	Length       int
+}
+
+type PromptConfig struct { // Настройки шаблонов запроса
+	Templates map[string]string // Шаблоны text/template по имени
+	Sources   map[string]string // Имя шаблона для источника, ключ - ID или название источника
+	Channels  map[string]string // Имя шаблона для канала, ключ - ID чата
+	Default   string            // Текст шаблона default если он не задан в Templates
+	Length    int               // Сколько предложений должно быть в саммари по умолчанию
+	Translate bool              // Саммари и заголовок переводятся на язык канала, иначе саммари пишется на языке статьи
+}
+
+type Prompts struct { // Шаблоны запроса к модели и правила их выбора
+	templates map[string]*template.Template
+	sources   map[string]string
+	channels  map[string]string
+	length    int
+	translate bool
+}
+
+func NewPrompts(cfg PromptConfig) (*Prompts, error) { // Конструктор для структуры Prompts, шаблоны проверяются сразу, чтобы ошибка в конфиге не всплыла при публикации
+	texts := map[string]string{defaultPromptName: DefaultPromptTemplate}
+	if cfg.Default != "" {
+		texts[defaultPromptName] = cfg.Default
+	}
+	for name, text := range cfg.Templates {
+		texts[strings.ToLower(name)] = text
+	}
+
+	prompts := &Prompts{
+		templates: make(map[string]*template.Template, len(texts)),
+		sources:   make(map[string]string, len(cfg.Sources)),
+		channels:  make(map[string]string, len(cfg.Channels)),
+		length:    cfg.Length,
+		translate: cfg.Translate,
+	}
+	if prompts.length <= 0 {
+		prompts.length = defaultSentences
+	}
+
+	for name, text := range texts {
+		tmpl, err := template.New(name).Option("missingkey=error").Parse(text)
+		if err != nil {
+			return nil, fmt.Errorf("prompt template %s: %w", name, err)
+		}
+		prompts.templates[name] = tmpl
+	}
+
+	for _, selection := range []struct {
+		from map[string]string
+		to   map[string]string
+	}{{cfg.Sources, prompts.sources}, {cfg.Channels, prompts.channels}} {
+		for key, name := range selection.from {
+			name = strings.ToLower(name)
+			if _, ok := prompts.templates[name]; !ok {
+				return nil, fmt.Errorf("prompt template %q for %q is not defined", name, key)
+			}
+			selection.to[strings.ToLower(key)] = name
+		}
+	}
+
+	return prompts, nil
+}
+
+func LoadPromptTemplates(dir string) (map[string]string, error) { // Функция читает шаблоны из файлов <имя>.tmpl каталога dir, пустой dir - шаблонов нет
+	if dir == "" {
+		return nil, nil
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.tmpl"))
+	if err != nil {
+		return nil, err
+	}
+
+	templates := make(map[string]string, len(files))
+	for _, file := range files {
+		text, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+
+		templates[strings.TrimSuffix(filepath.Base(file), ".tmpl")] = string(text)
+	}
+
+	return templates, nil
+}
+
+var defaultPrompts, _ = NewPrompts(PromptConfig{}) // Шаблоны для провайдеров созданных без настроек
+
+func (p *Prompts) Render(data PromptData) (string, error) { // Метод выбирает шаблон для источника или канала и подставляет в него данные статьи
+	if p == nil {
+		p = defaultPrompts
+	}
+
+	length := data.Length
+	if length <= 0 {
+		length = p.length
+	}
+
+	language, ok := languageNames[data.Language]
+	if !ok || !p.translate { // Без перевода модель отвечает на языке статьи
+		language, data.Language = "the language of the article", ""
+	}
+
+	var text strings.Builder
+	if err := p.templates[p.selectTemplate(data)].Execute(&text, promptVars{
+		Title:        data.Title,
+		Source:       data.Source,
+		Language:     language,
+		LanguageCode: data.Language,
+		Length:       length,
+	}); err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(text.String()), nil
+}
+
+func (p *Prompts) Translates() bool { // Метод сообщает что саммари и заголовки переводятся на язык канала
+	if p == nil {
+		p = defaultPrompts
+	}
+
+	return p.translate
+}
+
+func (p *Prompts) Hash(data PromptData) string { // Метод возвращает хэш запроса для статьи, при смене шаблона сохраненное саммари строится заново
+	prompt, err := p.Render(data)
+	if err != nil {
+		return ""
+	}
+
+	return PromptHash(prompt)
+}
+
+func (p *Prompts) selectTemplate(data PromptData) string { // Шаблон источника важнее шаблона канала, так у отдельных лент может быть свой стиль
+	for _, key := range []string{strconv.FormatInt(data.SourceID, 10), strings.ToLower(data.Source)} {
+		if name, ok := p.sources[key]; ok {
+			return name
+		}
+	}
+
+	if name, ok := p.channels[strconv.FormatInt(data.ChatID, 10)]; ok {
+		return name
+	}
+
+	return defaultPromptName
+}
+
+type promptDataKey struct{}
+
+func WithPromptData(ctx context.Context, data PromptData) context.Context { // Функция сохраняет данные статьи для шаблона запроса в контекст
+	return context.WithValue(ctx, promptDataKey{}, data)
+}
+
+func promptDataFromContext(ctx context.Context) PromptData { // Функция возвращает данные статьи из контекста, без них шаблон получает только язык и длину по умолчанию
+	data, _ := ctx.Value(promptDataKey{}).(PromptData)
+
+	return data
+}
@@ -0,0 +1,109 @@
+package summary
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+type Summarizer interface { // Интерфейс провайдера саммари, совпадает с notifier.Summarizer
+	Summarize(ctx context.Context, text string) (string, error)
+}
+
+type ProviderConfig struct { // Настройки провайдера саммари, пустые поля заменяются значениями по умолчанию провайдера
+	Provider    string   // Имя провайдера в реестре, например openai или ollama
+	APIKey      string   // Ключ API, локальным серверам обычно не нужен
+	BaseURL     string   // Адрес OpenAI-совместимого API, например http://localhost:11434/v1
+	Model       string   // Модель которая генерирует саммари
+	Prompts     *Prompts // Шаблоны системного сообщения с инструкцией для модели, nil - шаблон по умолчанию
+	Temperature float32  // Температура генерации, от 0 до 2
+	MaxTokens   int      // Максимальная длина саммари в токенах
+	Sentences   int      // Сколько предложений выбирает экстрактивный провайдер textrank
+
+	Concurrency     int // Сколько запросов к API выполняется одновременно
+	TokensPerMinute int // Сколько токенов в минуту можно потратить, 0 - без ограничения
+
+	InputTokens int  // Бюджет на текст статьи в одном запросе, 0 - без ограничения
+	MapReduce   bool // Статья длиннее InputTokens делится на части, иначе обрезается
+	MaxChunks   int  // Сколько частей статьи обрабатывается в режиме MapReduce
+
+	Usage   UsageRecorder // Куда записывается расход токенов каждого запроса, nil - не записывается
+	Pricing Pricing       // Цены модели для подсчета стоимости запросов
+}
+
+type Factory func(cfg ProviderConfig) (Summarizer, error) // Функция создает провайдера по настройкам
+
+var (
+	registryMu sync.RWMutex
+	registry   = make(map[string]Factory) // Зарегистрированные провайдеры по имени
+)
+
+func Register(name string, factory Factory) { // Функция регистрирует провайдера, повторная регистрация имени считается ошибкой программиста
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	name = strings.ToLower(name)
+
+	if _, ok := registry[name]; ok {
+		panic(fmt.Sprintf("summary: provider %q is already registered", name))
+	}
+
+	registry[name] = factory
+}
+
+func Providers() []string { // Функция возвращает имена зарегистрированных провайдеров по алфавиту
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+func New(cfg ProviderConfig) (Summarizer, error) { // Функция создает провайдера саммари по имени из настроек
+	registryMu.RLock()
+	factory, ok := registry[strings.ToLower(cfg.Provider)]
+	registryMu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("unknown summarizer provider %q, available: %s", cfg.Provider, strings.Join(Providers(), ", "))
+	}
+
+	if cfg.Temperature < 0 || cfg.Temperature > 2 {
+		return nil, fmt.Errorf("summarizer temperature must be between 0 and 2, got %v", cfg.Temperature)
+	}
+
+	if cfg.MaxTokens < 0 {
+		return nil, fmt.Errorf("summarizer max tokens must not be negative, got %d", cfg.MaxTokens)
+	}
+
+	if cfg.Concurrency < 0 || cfg.TokensPerMinute < 0 || cfg.InputTokens < 0 || cfg.MaxChunks < 0 {
+		return nil, fmt.Errorf("summarizer concurrency, token budgets and max chunks must not be negative")
+	}
+
+	return factory(cfg)
+}
+
+type Disabled struct{} // Провайдер который не генерирует саммари, статьи публикуются только с заголовком
+
+func (Disabled) Summarize(ctx context.Context, text string) (string, error) {
+	return "", nil
+}
+
+func init() {
+	Register("none", func(cfg ProviderConfig) (Summarizer, error) { return Disabled{}, nil })
+}
+
+func PromptHash(prompt string) string { // Функция возвращает короткий хэш запроса к модели для сохранения вместе с саммари
+	sum := sha256.Sum256([]byte(prompt))
+
+	return hex.EncodeToString(sum[:8])
+}
@@ -0,0 +1,33 @@
+package summary
+
+import "testing"
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     ProviderConfig
+		wantErr bool
+	}{
+		{name: "openai", cfg: ProviderConfig{Provider: "openai", APIKey: "sk-test"}},
+		{name: "openai without key", cfg: ProviderConfig{Provider: "openai"}, wantErr: true},
+		{name: "ollama without key", cfg: ProviderConfig{Provider: "ollama"}},
+		{name: "provider name is case insensitive", cfg: ProviderConfig{Provider: "TextRank"}},
+		{name: "none", cfg: ProviderConfig{Provider: "none"}},
+		{name: "unknown provider", cfg: ProviderConfig{Provider: "gpt"}, wantErr: true},
+		{name: "temperature out of range", cfg: ProviderConfig{Provider: "textrank", Temperature: 2.5}, wantErr: true},
+		{name: "negative max tokens", cfg: ProviderConfig{Provider: "ollama", MaxTokens: -1}, wantErr: true},
+		{name: "negative budget", cfg: ProviderConfig{Provider: "ollama", TokensPerMinute: -1}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			summarizer, err := New(tt.cfg)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("New(%+v) error = %v, wantErr %v", tt.cfg, err, tt.wantErr)
+			}
+			if !tt.wantErr && summarizer == nil {
+				t.Errorf("New(%+v) returned nil summarizer", tt.cfg)
+			}
+		})
+	}
+}
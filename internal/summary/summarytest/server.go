@@ -0,0 +1,134 @@
+package summarytest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/speeddem0n/GoNewsBot/internal/summary"
+)
+
+const DefaultReply = "Краткое содержание статьи." // Ответ Server если не задан другой
+
+type ReplyFunc func(req openai.ChatCompletionRequest) (string, error) // Функция формирует ответ модели на запрос, ошибка возвращается клиенту как ошибка API
+
+type Server struct { // Локальная замена OpenAI-совместимого API (OpenAI, Ollama, llama.cpp) для проверки саммари без внешних сервисов
+	*httptest.Server
+
+	mu       sync.Mutex
+	reply    ReplyFunc
+	requests []openai.ChatCompletionRequest
+}
+
+func NewServer() *Server { // Конструктор для структуры Server, сервер сразу запускается и должен быть остановлен методом Close
+	s := &Server{
+		reply: func(openai.ChatCompletionRequest) (string, error) { return DefaultReply, nil },
+	}
+
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+
+	return s
+}
+
+func (s *Server) BaseURL() string { // Метод возвращает адрес API для ProviderConfig.BaseURL
+	return s.URL + "/v1"
+}
+
+func (s *Server) Config(provider string) summary.ProviderConfig { // Метод возвращает настройки провайдера которые ходят в Server
+	return summary.ProviderConfig{
+		Provider: provider,
+		APIKey:   "test-key",
+		BaseURL:  s.BaseURL(),
+		Model:    "test-model",
+	}
+}
+
+func (s *Server) SetReply(text string) { // Метод задает ответ модели на все запросы
+	s.SetReplyFunc(func(openai.ChatCompletionRequest) (string, error) { return text, nil })
+}
+
+func (s *Server) SetReplyFunc(reply ReplyFunc) { // Метод задает функцию которая формирует ответ модели
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.reply = reply
+}
+
+func (s *Server) Requests() []openai.ChatCompletionRequest { // Метод возвращает полученные запросы в порядке получения
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]openai.ChatCompletionRequest(nil), s.requests...)
+}
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == "/v1/chat/completions":
+		s.chatCompletion(w, r)
+	case r.Method == http.MethodGet && r.URL.Path == "/v1/models":
+		writeJSON(w, http.StatusOK, openai.ModelsList{Models: []openai.Model{{ID: "test-model", Object: "model", OwnedBy: "summarytest"}}})
+	default:
+		writeError(w, http.StatusNotFound, "not found: "+r.Method+" "+r.URL.Path+" is not supported by summarytest.Server")
+	}
+}
+
+func (s *Server) chatCompletion(w http.ResponseWriter, r *http.Request) {
+	var req openai.ChatCompletionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	s.requests = append(s.requests, req)
+	reply := s.reply
+	s.mu.Unlock()
+
+	content, err := reply(req)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	promptTokens := 0
+	for _, message := range req.Messages {
+		promptTokens += len(strings.Fields(message.Content)) // Токены считаются по словам, точность тут не важна
+	}
+	completionTokens := len(strings.Fields(content))
+
+	writeJSON(w, http.StatusOK, openai.ChatCompletionResponse{
+		ID:      "chatcmpl-summarytest",
+		Object:  "chat.completion",
+		Created: time.Now().Unix(),
+		Model:   req.Model,
+		Choices: []openai.ChatCompletionChoice{{
+			Index:        0,
+			Message:      openai.ChatCompletionMessage{Role: openai.ChatMessageRoleAssistant, Content: content},
+			FinishReason: openai.FinishReasonStop,
+		}},
+		Usage: openai.Usage{
+			PromptTokens:     promptTokens,
+			CompletionTokens: completionTokens,
+			TotalTokens:      promptTokens + completionTokens,
+		},
+	})
+}
+
+func writeJSON(w http.ResponseWriter, code int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func writeError(w http.ResponseWriter, code int, message string) { // Функция отвечает ошибкой в формате OpenAI API
+	writeJSON(w, code, map[string]any{
+		"error": map[string]any{
+			"message": message,
+			"type":    "summarytest_error",
+		},
+	})
+}
@@ -0,0 +1,323 @@
+package summary
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+const (
+	defaultSentences  = 3    // Сколько предложений попадает в саммари если не задано
+	maxRankSentences  = 200  // Сколько первых предложений статьи участвуют в ранжировании, сравнение предложений квадратичное
+	minSentenceWords  = 4    // Короткие предложения (подписи, заголовки) в саммари не берутся
+	textRankDamping   = 0.85 // Коэффициент затухания PageRank
+	textRankEpsilon   = 1e-4 // Точность при которой итерации PageRank останавливаются
+	textRankMaxRounds = 100
+)
+
+type TextRankSummarizer struct { // Экстрактивный саммаризатор, выбирает из статьи самые важные предложения алгоритмом TextRank и работает без внешних сервисов
+	sentences int
+}
+
+func NewTextRankSummarizer(sentences int) *TextRankSummarizer { // Конструктор для структуры TextRankSummarizer, sentences - сколько предложений в саммари
+	if sentences <= 0 {
+		sentences = defaultSentences
+	}
+
+	return &TextRankSummarizer{sentences: sentences}
+}
+
+func init() {
+	Register("textrank", func(cfg ProviderConfig) (Summarizer, error) { return NewTextRankSummarizer(cfg.Sentences), nil })
+}
+
+func (s *TextRankSummarizer) Summarize(ctx context.Context, text string) (string, error) { // Метод выбирает s.sentences самых важных предложений и возвращает их в порядке следования в статье
+	sentences := splitSentences(text)
+	if len(sentences) > maxRankSentences {
+		sentences = sentences[:maxRankSentences]
+	}
+
+	candidates := make([]rankedSentence, 0, len(sentences))
+	for i, sentence := range sentences {
+		words := sentenceWords(sentence)
+		if len(words) < minSentenceWords {
+			continue
+		}
+
+		candidates = append(candidates, rankedSentence{index: i, text: sentence, words: words})
+	}
+
+	if len(candidates) <= s.sentences { // Статья и так короткая, ранжировать нечего
+		return joinSentences(candidates), nil
+	}
+
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	rankSentences(candidates)
+
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].rank > candidates[j].rank })
+	top := candidates[:s.sentences]
+	sort.Slice(top, func(i, j int) bool { return top[i].index < top[j].index }) // Возвращаем предложения в исходном порядке, так саммари читается связно
+
+	return joinSentences(top), nil
+}
+
+type rankedSentence struct {
+	index int                 // Номер предложения в статье
+	text  string              // Предложение как оно есть в статье
+	words map[string]struct{} // Основы значимых слов предложения
+	rank  float64
+}
+
+func rankSentences(sentences []rankedSentence) { // Функция считает PageRank на графе предложений, вес ребра - похожесть предложений
+	n := len(sentences)
+
+	weights := make([][]float64, n)
+	totals := make([]float64, n) // Сумма весов исходящих ребер каждого предложения
+	for i := range weights {
+		weights[i] = make([]float64, n)
+	}
+
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			w := similarity(sentences[i].words, sentences[j].words)
+			weights[i][j], weights[j][i] = w, w
+			totals[i] += w
+			totals[j] += w
+		}
+	}
+
+	ranks := make([]float64, n)
+	for i := range ranks {
+		ranks[i] = 1
+	}
+
+	for round := 0; round < textRankMaxRounds; round++ {
+		var delta float64
+
+		next := make([]float64, n)
+		for i := 0; i < n; i++ {
+			var sum float64
+			for j := 0; j < n; j++ {
+				if weights[j][i] > 0 {
+					sum += weights[j][i] / totals[j] * ranks[j]
+				}
+			}
+
+			next[i] = 1 - textRankDamping + textRankDamping*sum
+			delta = math.Max(delta, math.Abs(next[i]-ranks[i]))
+		}
+
+		ranks = next
+		if delta < textRankEpsilon {
+			break
+		}
+	}
+
+	for i := range sentences {
+		sentences[i].rank = ranks[i]
+	}
+}
+
+func similarity(a, b map[string]struct{}) float64 { // Похожесть предложений из оригинальной статьи TextRank: общие слова деленные на логарифмы длин
+	var common int
+	for word := range a {
+		if _, ok := b[word]; ok {
+			common++
+		}
+	}
+
+	if common == 0 {
+		return 0
+	}
+
+	return float64(common) / (math.Log(float64(len(a))+1) + math.Log(float64(len(b))+1))
+}
+
+func joinSentences(sentences []rankedSentence) string {
+	texts := make([]string, 0, len(sentences))
+	for _, sentence := range sentences {
+		texts = append(texts, sentence.text)
+	}
+
+	return strings.Join(texts, " ")
+}
+
+func splitSentences(text string) []string { // Функция делит текст на предложения по знакам конца предложения и пустым строкам
+	runes := []rune(text)
+
+	var sentences []string
+	for _, bounds := range sentenceBounds(runes) {
+		sentences = append(sentences, strings.Join(strings.Fields(string(runes[bounds[0]:bounds[1]])), " "))
+	}
+
+	return sentences
+}
+
+func sentenceBounds(runes []rune) [][2]int { // Функция возвращает начало и конец каждого непустого предложения в runes, пробелы и переносы внутри предложений не трогаются
+	var (
+		bounds [][2]int
+		start  int
+	)
+
+	flush := func(end int) {
+		if strings.TrimSpace(string(runes[start:end])) != "" {
+			bounds = append(bounds, [2]int{start, end})
+		}
+		start = end
+	}
+
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+
+		if r == '\n' && i+1 < len(runes) && runes[i+1] == '\n' { // Абзац заканчивает предложение, даже если в конце нет точки
+			flush(i)
+			continue
+		}
+
+		if !strings.ContainsRune(".!?…", r) {
+			continue
+		}
+
+		end := i + 1
+		for end < len(runes) && strings.ContainsRune(".!?…\"»”)", runes[end]) { // Многоточия, кавычки и скобки после точки остаются в предложении
+			end++
+		}
+
+		if end < len(runes) && !unicode.IsSpace(runes[end]) { // Точка внутри слова или числа: 3.14, example.com
+			continue
+		}
+
+		next := end
+		for next < len(runes) && unicode.IsSpace(runes[next]) {
+			next++
+		}
+
+		if r == '.' && next < len(runes) && unicode.IsLower(runes[next]) { // Сокращение: т.е. это, e.g. this
+			continue
+		}
+
+		if r == '.' && isAbbreviation(runes[start:i]) {
+			continue
+		}
+
+		flush(end)
+		i = end - 1
+	}
+
+	flush(len(runes))
+
+	return bounds
+}
+
+func isAbbreviation(before []rune) bool { // Функция проверяет что точка стоит после сокращения или инициала: Mr. Smith, А. С. Пушкин
+	i := len(before)
+	for i > 0 && unicode.IsLetter(before[i-1]) {
+		i--
+	}
+
+	word := strings.ToLower(string(before[i:]))
+
+	return len([]rune(word)) == 1 || abbreviations[word]
+}
+
+var abbreviations = map[string]bool{
+	"mr": true, "mrs": true, "ms": true, "dr": true, "prof": true, "inc": true, "ltd": true, "vs": true, "etc": true, "jr": true, "sr": true,
+	"г": true, "гг": true, "руб": true, "тыс": true, "млн": true, "млрд": true, "им": true, "ул": true, "др": true, "пр": true, "см": true,
+}
+
+func sentenceWords(sentence string) map[string]struct{} { // Функция возвращает основы значимых слов предложения, язык определяется по алфавиту каждого слова
+	words := make(map[string]struct{})
+
+	for _, word := range strings.FieldsFunc(strings.ToLower(sentence), func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) }) {
+		if stopWords[word] {
+			continue
+		}
+
+		if isCyrillic(word) {
+			word = stemRussian(word)
+		} else {
+			word = stemEnglish(word)
+		}
+
+		words[word] = struct{}{}
+	}
+
+	return words
+}
+
+func isCyrillic(word string) bool {
+	for _, r := range word {
+		if unicode.Is(unicode.Cyrillic, r) {
+			return true
+		}
+	}
+
+	return false
+}
+
+var russianEndings = []string{ // Окончания по убыванию длины, чтобы сначала отрезались длинные
+	"иями", "ями", "ами", "ого", "его", "ому", "ему", "ыми", "ими", "ться", "ется", "ются", "ится", "ятся",
+	"ешь", "ете", "ишь", "ите", "ает", "яет", "ует", "ают", "яют", "уют", "ила", "ило", "или", "ала", "ало", "али",
+	"ость", "ости", "ией", "ием", "иях", "ях", "ах", "ов", "ев", "ой", "ей", "ий", "ый", "ая", "яя", "ое", "ее",
+	"ую", "юю", "ом", "ем", "ам", "ям", "ых", "их", "ть", "ет", "ит", "ут", "ют", "ат", "ят", "ия", "ие", "ии",
+	"ы", "и", "а", "я", "о", "е", "у", "ю", "ь", "й",
+}
+
+func init() {
+	sort.SliceStable(russianEndings, func(i, j int) bool { return len([]rune(russianEndings[i])) > len([]rune(russianEndings[j])) })
+}
+
+func stemRussian(word string) string { // Легкий стеммер: отрезает одно окончание, оставляя основу не короче трех букв
+	runes := []rune(word)
+
+	for _, ending := range russianEndings {
+		endingLen := len([]rune(ending))
+		if len(runes)-endingLen >= 3 && strings.HasSuffix(word, ending) {
+			return string(runes[:len(runes)-endingLen])
+		}
+	}
+
+	return word
+}
+
+var englishSuffixes = []string{"ational", "ization", "fulness", "ousness", "ations", "ation", "ments", "ment", "ingly", "edly", "ness", "ies", "ing", "ers", "ed", "er", "ly", "es", "s"}
+
+func stemEnglish(word string) string { // Легкий стеммер: отрезает один суффикс, оставляя основу не короче трех букв
+	for _, suffix := range englishSuffixes {
+		if len(word)-len(suffix) >= 3 && strings.HasSuffix(word, suffix) {
+			if suffix == "ies" {
+				return strings.TrimSuffix(word, suffix) + "y"
+			}
+			return strings.TrimSuffix(word, suffix)
+		}
+	}
+
+	return word
+}
+
+var stopWords = func() map[string]bool { // Частые слова русского и английского языка, которые не говорят о смысле предложения
+	words := make(map[string]bool)
+
+	for _, word := range strings.Fields(`
+		и в во не что он на я с со как а то все она так его но да ты к у же вы за бы по только ее мне было вот от меня еще нет о из ему
+		теперь когда даже ну вдруг ли если уже или ни быть был него до вас нибудь опять уж вам ведь там потом себя ничего ей может они
+		тут где есть надо ней для мы тебя их чем была сам чтоб без будто чего раз тоже себе под будет ж тогда кто этот того потому этого
+		какой совсем ним здесь этом один почти мой тем чтобы нее сейчас были куда зачем всех никогда можно при наконец два об другой хоть
+		после над больше тот через эти нас про всего них какая много разве три эту моя впрочем хорошо свою этой перед иногда лучше чуть
+		том нельзя такой им более всегда конечно всю между это также которые который которая которых которое является
+		a an the and or but if then else of at by for with about against between into through during before after above below to from
+		up down in out on off over under again further once here there when where why how all any both each few more most other some such
+		no nor not only own same so than too very can will just don should now is are was were be been being have has had having do does
+		did doing i me my we our you your he him his she her it its they them their what which who whom this that these those am would
+		could also as
+	`) {
+		words[word] = true
+	}
+
+	return words
+}()
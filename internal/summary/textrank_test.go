@@ -0,0 +1,116 @@
+package summary
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSplitSentences(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{name: "empty", text: "", want: nil},
+		{name: "simple", text: "Go 1.24 вышел. В нем новые итераторы! Что дальше?", want: []string{"Go 1.24 вышел.", "В нем новые итераторы!", "Что дальше?"}},
+		{name: "no final dot", text: "First sentence. Second sentence", want: []string{"First sentence.", "Second sentence"}},
+		{name: "dots inside numbers and domains", text: "Pi is 3.14 and the site is example.com today. Done.", want: []string{"Pi is 3.14 and the site is example.com today.", "Done."}},
+		{name: "ellipsis and quotes", text: `He said "wait..." Then left. Она сказала «нет!» Потом ушла.`, want: []string{`He said "wait..."`, "Then left.", "Она сказала «нет!»", "Потом ушла."}},
+		{name: "english abbreviation", text: "Mr. Smith met Dr. Brown. They talked.", want: []string{"Mr. Smith met Dr. Brown.", "They talked."}},
+		{name: "initials", text: "А. С. Пушкин родился в 1799 г. В Москве.", want: []string{"А. С. Пушкин родился в 1799 г. В Москве."}},
+		{name: "lower case after dot", text: "Это т.е. пример, e.g. this one. Next.", want: []string{"Это т.е. пример, e.g. this one.", "Next."}},
+		{name: "paragraph without dot", text: "Заголовок статьи\n\nПервый абзац текста.", want: []string{"Заголовок статьи", "Первый абзац текста."}},
+		{name: "whitespace is normalized", text: "  Line one\ncontinues   here.\tLine two.  ", want: []string{"Line one continues here.", "Line two."}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := splitSentences(tt.text); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("splitSentences(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStem(t *testing.T) {
+	tests := []struct {
+		word string
+		want string
+	}{
+		{word: "серверами", want: "сервер"},
+		{word: "сервера", want: "сервер"},
+		{word: "сервер", want: "сервер"},
+		{word: "новостей", want: "новост"},
+		{word: "код", want: "код"},
+		{word: "servers", want: "serv"},
+		{word: "server", want: "serv"},
+		{word: "released", want: "releas"},
+		{word: "libraries", want: "library"},
+		{word: "running", want: "runn"},
+		{word: "go", want: "go"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.word, func(t *testing.T) {
+			stem := stemEnglish
+			if isCyrillic(tt.word) {
+				stem = stemRussian
+			}
+
+			if got := stem(tt.word); got != tt.want {
+				t.Errorf("stem(%q) = %q, want %q", tt.word, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTextRankSummarize(t *testing.T) {
+	article := strings.Join([]string{
+		"The Go team released Go 1.24 with generic type aliases.",
+		"Weather in the city was sunny and warm all week.",
+		"Generic type aliases in Go 1.24 simplify large refactorings.",
+		"A local bakery opened a new shop downtown yesterday.",
+		"The Go 1.24 release also improves map performance with generic code.",
+		"Short one.",
+	}, " ")
+
+	tests := []struct {
+		name      string
+		sentences int
+		text      string
+		want      string
+	}{
+		{
+			name:      "top sentences in article order",
+			sentences: 2,
+			text:      article,
+			want:      "The Go team released Go 1.24 with generic type aliases. Generic type aliases in Go 1.24 simplify large refactorings.",
+		},
+		{
+			name:      "short article is returned without short sentences",
+			sentences: 3,
+			text:      "Go 1.24 ships new language features. Short one. The release adds generic type aliases.",
+			want:      "Go 1.24 ships new language features. The release adds generic type aliases.",
+		},
+		{
+			name:      "empty article",
+			sentences: 3,
+			text:      "",
+			want:      "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewTextRankSummarizer(tt.sentences).Summarize(context.Background(), tt.text)
+			if err != nil {
+				t.Fatalf("Summarize() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Summarize() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package summary
+
+import (
+	"context"
+	"unicode"
+)
+
+const translatePrompt = "Translate the news headline from the user message into %s. Reply with the translation only, without quotes or comments." // Инструкция для перевода заголовка, %s - название языка
+
+type Translator interface { // Провайдер который умеет переводить текст, например OpenAISummarizer
+	Translate(ctx context.Context, text, language string) (string, error)
+}
+
+func DetectLanguage(text string) string { // Функция определяет язык текста по алфавиту: ru для кириллицы, en для латиницы, пустая строка если букв нет
+	var cyrillic, latin int
+
+	for _, r := range text {
+		switch {
+		case unicode.Is(unicode.Cyrillic, r):
+			cyrillic++
+		case unicode.Is(unicode.Latin, r):
+			latin++
+		}
+	}
+
+	switch {
+	case cyrillic == 0 && latin == 0:
+		return ""
+	case cyrillic*2 >= latin: // В русских заголовках часто встречаются латинские названия продуктов: "Apple представила iPhone"
+		return "ru"
+	default:
+		return "en"
+	}
+}
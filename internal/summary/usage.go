@@ -0,0 +1,94 @@
+package summary
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/speeddem0n/GoNewsBot/internal/models"
+)
+
+const budgetCheckInterval = time.Minute // Как часто Budget перечитывает расход из хранилища
+
+type UsageRecorder interface { // Хранилище расхода токенов, например storage.UsagePostgresStorage
+	Record(ctx context.Context, usage models.SummaryUsage) error
+}
+
+type Pricing struct { // Цены модели в долларах за миллион токенов
+	Prompt     float64
+	Completion float64
+}
+
+func (p Pricing) Cost(promptTokens, completionTokens int) float64 { // Метод считает стоимость запроса
+	return (float64(promptTokens)*p.Prompt + float64(completionTokens)*p.Completion) / 1_000_000
+}
+
+type CostProvider interface { // Источник расходов для Budget
+	Cost(ctx context.Context, since time.Time) (float64, error)
+}
+
+type Budget struct { // Месячный бюджет на саммари, nil - без ограничения
+	costs CostProvider
+	limit float64
+
+	mu       sync.Mutex
+	checked  time.Time
+	exceeded bool
+	now      func() time.Time
+}
+
+func NewBudget(costs CostProvider, limit float64) *Budget { // Конструктор для структуры Budget, без лимита возвращает nil
+	if costs == nil || limit <= 0 {
+		return nil
+	}
+
+	return &Budget{costs: costs, limit: limit, now: time.Now}
+}
+
+func MonthStart(now time.Time) time.Time { // Функция возвращает начало месяца по UTC, расход в хранилище считается по дням UTC
+	now = now.UTC()
+
+	return time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+func (b *Budget) Limit() float64 { // Метод возвращает месячный лимит, 0 - без ограничения
+	if b == nil {
+		return 0
+	}
+
+	return b.limit
+}
+
+func (b *Budget) Exceeded(ctx context.Context) bool { // Метод сообщает что бюджет текущего месяца исчерпан, расход перечитывается не чаще раза в минуту
+	if b == nil {
+		return false
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.now()
+	if now.Sub(b.checked) < budgetCheckInterval && MonthStart(now).Equal(MonthStart(b.checked)) {
+		return b.exceeded
+	}
+
+	spent, err := b.costs.Cost(ctx, MonthStart(now))
+	if err != nil { // Из-за недоступной БД саммари не должны пропадать, поэтому считаем что бюджет есть
+		logrus.Errorf("failed to get summarizer spending: %v", err)
+		return b.exceeded
+	}
+
+	exceeded := spent >= b.limit
+	if exceeded != b.exceeded {
+		if exceeded {
+			logrus.Warnf("summarizer monthly budget exceeded: $%.2f of $%.2f, switching to fallback", spent, b.limit)
+		} else {
+			logrus.Infof("summarizer monthly budget available again: $%.2f of $%.2f", spent, b.limit)
+		}
+	}
+
+	b.checked, b.exceeded = now, exceeded
+
+	return exceeded
+}
@@ -0,0 +1,97 @@
+package summary
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+type fakeCosts struct {
+	spent float64
+	err   error
+	since time.Time // Начало периода из последнего запроса
+	calls int
+}
+
+func (c *fakeCosts) Cost(ctx context.Context, since time.Time) (float64, error) {
+	c.calls++
+	c.since = since
+	return c.spent, c.err
+}
+
+func TestNewBudget(t *testing.T) {
+	if NewBudget(&fakeCosts{}, 0) != nil {
+		t.Error("NewBudget() without limit is not nil")
+	}
+	if NewBudget(nil, 10) != nil {
+		t.Error("NewBudget() without costs is not nil")
+	}
+
+	var budget *Budget
+	if budget.Exceeded(context.Background()) || budget.Limit() != 0 {
+		t.Error("nil budget is limited")
+	}
+}
+
+func TestBudgetExceeded(t *testing.T) {
+	steps := []struct {
+		name      string
+		after     time.Duration // Сколько прошло с предыдущей проверки
+		spent     float64
+		err       error
+		want      bool
+		wantCalls int // Сколько всего раз прочитан расход
+	}{
+		{name: "under limit", spent: 9.99, want: false, wantCalls: 1},
+		{name: "cached within interval", after: 30 * time.Second, spent: 10, want: false, wantCalls: 1},
+		{name: "limit reached", after: 30 * time.Second, spent: 10, want: true, wantCalls: 2},
+		{name: "storage error keeps last state", after: time.Minute, err: errors.New("db is down"), want: true, wantCalls: 3},
+		{name: "storage error is retried on next check", after: time.Second, spent: 12, want: true, wantCalls: 4},
+		{name: "new month resets budget", after: 20 * 24 * time.Hour, spent: 0, want: false, wantCalls: 5},
+		{name: "storage error fails open", after: time.Minute, err: errors.New("db is down"), want: false, wantCalls: 6},
+	}
+
+	var (
+		costs  = &fakeCosts{}
+		now    = time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+		budget = NewBudget(costs, 10)
+	)
+	budget.now = func() time.Time { return now }
+
+	for _, step := range steps {
+		now = now.Add(step.after)
+		costs.spent, costs.err = step.spent, step.err
+
+		if got := budget.Exceeded(context.Background()); got != step.want {
+			t.Errorf("%s: Exceeded() = %v, want %v", step.name, got, step.want)
+		}
+		if costs.calls != step.wantCalls {
+			t.Errorf("%s: costs read %d times, want %d", step.name, costs.calls, step.wantCalls)
+		}
+		if want := MonthStart(now); !costs.since.Equal(want) {
+			t.Errorf("%s: costs since %s, want %s", step.name, costs.since, want)
+		}
+	}
+}
+
+func TestChainSkipsExceededBudget(t *testing.T) {
+	var (
+		costs    = &fakeCosts{spent: 15}
+		primary  = &fakeSummarizer{reply: "primary"}
+		fallback = &fakeSummarizer{reply: "fallback"}
+	)
+
+	chain := NewChain(ChainConfig{},
+		ChainProvider{Name: "openai", Summarizer: primary, Budget: NewBudget(costs, 10)},
+		ChainProvider{Name: "textrank", Summarizer: fallback},
+	)
+
+	summary, err := chain.Generate(context.Background(), "text")
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if summary.Provider != "textrank" || primary.calls != 0 {
+		t.Errorf("provider = %q, primary calls = %d, want textrank without calling primary", summary.Provider, primary.calls)
+	}
+}